@@ -6,6 +6,12 @@ import (
 	"time"
 
 	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/configs"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/awesomeapi"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/brasilapi"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/ibge"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/opencep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openmeteo"
 	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
 	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
 	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/weatherapi"
@@ -15,14 +21,54 @@ import (
 func main() {
 	cfg := configs.LoadConfig()
 
-	httpClient := &http.Client{Timeout: 10 * time.Second}
+	httpClients := newHTTPClients(cfg)
 
-	viaCEPClient := viacep.NewClient(httpClient)
-	geoClient := openweathermap.NewClient(httpClient, cfg.OpenWeatherMapAPIKey)
-	weatherClient := weatherapi.NewClient(httpClient, cfg.WeatherAPIKey)
+	cepClient := viacep.NewFailoverClient(cfg.CEPProviderTimeout, cepProviders(cfg.CEPProviders, httpClients)...)
+	viaCEPClient := viacep.NewCachedClient(
+		viacep.NewCoalescingClient(cepClient),
+		cfg.CEPCacheMaxEntries, cfg.CEPCacheTTL, cfg.CEPCacheNegativeTTL,
+	)
+	var owmClient openweathermap.Client
+	if cfg.OpenWeatherMapAPIKey != "" {
+		owmClient = openweathermap.NewCachedClient(
+			openweathermap.NewCoalescingClient(openweathermap.NewClient(httpClients.get("openweathermap"), cfg.OpenWeatherMapAPIKey)),
+			cfg.GeoCacheMaxEntries, cfg.GeoCacheTTL,
+		)
+	}
+	geoClient := ibge.NewGeocoder(owmClient)
+	if owmClient == nil && !geoClient.Complete() {
+		log.Fatal("OPENWEATHERMAP_API_KEY is required while the embedded IBGE dataset is incomplete. Run go generate ./internal/clients/ibge or set the key")
+	}
+	weatherClient := weatherapi.NewCachedClient(
+		weatherapi.NewCoalescingClient(weatherapi.NewFailoverClient(cfg.WeatherProviderTimeout, weatherProviders(cfg, httpClients)...)),
+		cfg.WeatherCacheMaxEntries, cfg.WeatherCacheTTL, cfg.WeatherCachePrecision,
+	)
+
+	var forecastClient weatherapi.ForecastClient
+	var historyClient weatherapi.HistoryClient
+	if cfg.WeatherAPIKey != "" {
+		forecastClient = weatherapi.NewForecastClient(httpClients.get("weatherapi"), cfg.WeatherAPIKey)
+		historyClient = weatherapi.NewHistoryClient(httpClients.get("weatherapi"), cfg.WeatherAPIKey)
+	} else {
+		log.Println("WEATHERAPI_KEY not set: /api/forecast and /api/history are disabled")
+	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/api/weather", handlers.NewWeatherHandler(viaCEPClient, geoClient, weatherClient))
+	mux.Handle("/api/weather/batch", handlers.NewBatchHandler(viaCEPClient, geoClient, weatherClient, handlers.BatchConfig{
+		MaxSize:     cfg.BatchMaxSize,
+		Concurrency: cfg.BatchConcurrency,
+	}))
+	mux.Handle("/api/reverse", handlers.NewReverseHandler(viaCEPClient, geoClient, weatherClient))
+	mux.Handle("/api/cep/search", handlers.NewAddressSearchHandler(viaCEPClient, geoClient, weatherClient))
+	mux.Handle("/api/address", handlers.NewAddressHandler(viaCEPClient, geoClient))
+	mux.Handle("/api/forecast", handlers.NewForecastHandler(viaCEPClient, geoClient, forecastClient))
+	mux.Handle("/api/history", handlers.NewHistoryHandler(viaCEPClient, geoClient, historyClient))
+	if cfg.AdminToken != "" {
+		mux.Handle("/admin/breakers", handlers.RequireAdminToken(cfg.AdminToken, handlers.NewBreakerStatusHandler(httpClients.breakers...)))
+	} else {
+		log.Println("ADMIN_TOKEN not set: /admin/breakers is disabled")
+	}
 
 	// Root endpoint
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
@@ -32,11 +78,50 @@ func main() {
 
 	log.Printf("Server starting on port %s", cfg.Port)
 	addr := ":" + cfg.Port
-	if err := http.ListenAndServe(addr, logRequests(mux)); err != nil {
+	if err := http.ListenAndServe(addr, handlers.RequestID(logRequests(mux))); err != nil {
 		log.Fatalf("server error: %v", err)
 	}
 }
 
+// httpClients holds one HTTP client per upstream provider, each guarded by
+// its own circuit breaker so an outage in one provider does not affect the
+// others.
+type httpClients struct {
+	cfg      *configs.Config
+	clients  map[string]*http.Client
+	breakers []*clients.Breaker
+}
+
+func newHTTPClients(cfg *configs.Config) *httpClients {
+	return &httpClients{cfg: cfg, clients: make(map[string]*http.Client)}
+}
+
+func (h *httpClients) get(provider string) *http.Client {
+	if c, ok := h.clients[provider]; ok {
+		return c
+	}
+
+	breaker := clients.NewBreaker(provider, clients.BreakerConfig{
+		FailureRatio: h.cfg.BreakerFailureRatio,
+		MinRequests:  h.cfg.BreakerMinRequests,
+		Window:       h.cfg.BreakerWindow,
+		CoolDown:     h.cfg.BreakerCoolDown,
+	})
+	h.breakers = append(h.breakers, breaker)
+
+	retry := clients.NewRetryTransport(http.DefaultTransport, clients.RetryConfig{
+		MaxAttempts: h.cfg.RetryMaxAttempts,
+		BaseDelay:   h.cfg.RetryBaseDelay,
+		MaxDelay:    h.cfg.RetryMaxDelay,
+	})
+	c := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: clients.NewBreakerTransport(retry, breaker),
+	}
+	h.clients[provider] = c
+	return c
+}
+
 func logRequests(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -44,3 +129,41 @@ func logRequests(next http.Handler) http.Handler {
 		log.Printf("%s %s %s", r.Method, r.URL.Path, time.Since(start))
 	})
 }
+
+func cepProviders(names []string, httpClients *httpClients) []viacep.Provider {
+	providers := make([]viacep.Provider, 0, len(names))
+	for _, name := range names {
+		var c viacep.Client
+		switch name {
+		case "viacep":
+			c = viacep.NewClient(httpClients.get(name))
+		case "brasilapi":
+			c = brasilapi.NewClient(httpClients.get(name))
+		case "opencep":
+			c = opencep.NewClient(httpClients.get(name))
+		case "awesomeapi":
+			c = awesomeapi.NewClient(httpClients.get(name))
+		default:
+			log.Fatalf("unknown CEP provider %q in CEP_PROVIDERS", name)
+		}
+		providers = append(providers, viacep.Provider{Name: name, Client: c})
+	}
+	return providers
+}
+
+func weatherProviders(cfg *configs.Config, httpClients *httpClients) []weatherapi.Provider {
+	providers := make([]weatherapi.Provider, 0, len(cfg.WeatherProviders))
+	for _, name := range cfg.WeatherProviders {
+		var c weatherapi.Client
+		switch name {
+		case "weatherapi":
+			c = weatherapi.NewClient(httpClients.get(name), cfg.WeatherAPIKey)
+		case "openmeteo":
+			c = openmeteo.NewClient(httpClients.get(name))
+		default:
+			log.Fatalf("unknown weather provider %q in WEATHER_PROVIDERS", name)
+		}
+		providers = append(providers, weatherapi.Provider{Name: name, Client: c})
+	}
+	return providers
+}
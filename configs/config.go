@@ -2,6 +2,8 @@ package configs
 
 import (
 	"log"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -10,6 +12,37 @@ type Config struct {
 	Port                 string
 	WeatherAPIKey        string
 	OpenWeatherMapAPIKey string
+
+	WeatherProviders       []string
+	WeatherProviderTimeout time.Duration
+
+	RetryMaxAttempts int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+
+	BreakerFailureRatio float64
+	BreakerMinRequests  int
+	BreakerWindow       time.Duration
+	BreakerCoolDown     time.Duration
+
+	CEPProviders       []string
+	CEPProviderTimeout time.Duration
+
+	CEPCacheMaxEntries  int
+	CEPCacheTTL         time.Duration
+	CEPCacheNegativeTTL time.Duration
+
+	GeoCacheMaxEntries int
+	GeoCacheTTL        time.Duration
+
+	WeatherCacheMaxEntries int
+	WeatherCacheTTL        time.Duration
+	WeatherCachePrecision  int
+
+	BatchMaxSize     int
+	BatchConcurrency int
+
+	AdminToken string
 }
 
 func LoadConfig() *Config {
@@ -27,18 +60,109 @@ func LoadConfig() *Config {
 	}
 
 	weatherAPIKey := viper.GetString("WEATHERAPI_KEY")
+	weatherProviders := getList("WEATHER_PROVIDERS", []string{"weatherapi", "openmeteo"})
 	if weatherAPIKey == "" {
-		log.Fatal("WEATHERAPI_KEY is required. Please set it in .env file or environment variables")
+		weatherProviders = without(weatherProviders, "weatherapi")
+		log.Println("WEATHERAPI_KEY is not set, the weatherapi provider is disabled")
+	}
+	if len(weatherProviders) == 0 {
+		log.Fatal("No weather provider available. Set WEATHERAPI_KEY or add openmeteo to WEATHER_PROVIDERS")
 	}
 
 	openWeatherMapAPIKey := viper.GetString("OPENWEATHERMAP_API_KEY")
 	if openWeatherMapAPIKey == "" {
-		log.Fatal("OPENWEATHERMAP_API_KEY is required. Please set it in .env file or environment variables")
+		log.Println("OPENWEATHERMAP_API_KEY is not set, only the embedded IBGE dataset will be used for geocoding")
+	}
+
+	// A ratio of 0 or less would open the breakers with no failures at all,
+	// and one above 1 would never open them.
+	breakerFailureRatio := getFloat("BREAKER_FAILURE_RATIO", 0.5)
+	if breakerFailureRatio <= 0 || breakerFailureRatio > 1 {
+		log.Fatalf("BREAKER_FAILURE_RATIO must be greater than 0 and at most 1, got %v", breakerFailureRatio)
 	}
 
 	return &Config{
 		Port:                 port,
 		WeatherAPIKey:        weatherAPIKey,
 		OpenWeatherMapAPIKey: openWeatherMapAPIKey,
+
+		WeatherProviders:       weatherProviders,
+		WeatherProviderTimeout: getDuration("WEATHER_PROVIDER_TIMEOUT", 2*time.Second),
+
+		RetryMaxAttempts: getInt("RETRY_MAX_ATTEMPTS", 3),
+		RetryBaseDelay:   getDuration("RETRY_BASE_DELAY", 100*time.Millisecond),
+		RetryMaxDelay:    getDuration("RETRY_MAX_DELAY", time.Second),
+
+		BreakerFailureRatio: breakerFailureRatio,
+		BreakerMinRequests:  getInt("BREAKER_MIN_REQUESTS", 10),
+		BreakerWindow:       getDuration("BREAKER_WINDOW", 30*time.Second),
+		BreakerCoolDown:     getDuration("BREAKER_COOLDOWN", 15*time.Second),
+
+		CEPProviders:       getList("CEP_PROVIDERS", []string{"viacep", "brasilapi", "opencep", "awesomeapi"}),
+		CEPProviderTimeout: getDuration("CEP_PROVIDER_TIMEOUT", 2*time.Second),
+
+		CEPCacheMaxEntries:  getInt("CEP_CACHE_MAX_ENTRIES", 10000),
+		CEPCacheTTL:         getDuration("CEP_CACHE_TTL", 24*time.Hour),
+		CEPCacheNegativeTTL: getDuration("CEP_CACHE_NEGATIVE_TTL", 10*time.Minute),
+
+		GeoCacheMaxEntries: getInt("GEO_CACHE_MAX_ENTRIES", 5000),
+		GeoCacheTTL:        getDuration("GEO_CACHE_TTL", 7*24*time.Hour),
+
+		WeatherCacheMaxEntries: getInt("WEATHER_CACHE_MAX_ENTRIES", 10000),
+		WeatherCacheTTL:        getDuration("WEATHER_CACHE_TTL", 5*time.Minute),
+		WeatherCachePrecision:  getInt("WEATHER_CACHE_PRECISION", 2),
+
+		BatchMaxSize:     getInt("BATCH_MAX_SIZE", 1000),
+		BatchConcurrency: getInt("BATCH_CONCURRENCY", 10),
+
+		AdminToken: viper.GetString("ADMIN_TOKEN"),
+	}
+}
+
+func getInt(key string, def int) int {
+	if !viper.IsSet(key) || viper.GetString(key) == "" {
+		return def
+	}
+	return viper.GetInt(key)
+}
+
+func getFloat(key string, def float64) float64 {
+	if !viper.IsSet(key) || viper.GetString(key) == "" {
+		return def
+	}
+	return viper.GetFloat64(key)
+}
+
+func getDuration(key string, def time.Duration) time.Duration {
+	if !viper.IsSet(key) || viper.GetString(key) == "" {
+		return def
+	}
+	d, err := time.ParseDuration(viper.GetString(key))
+	if err != nil {
+		log.Fatalf("%s must be a valid duration (e.g. 30s, 5m, 24h): %v", key, err)
+	}
+	return d
+}
+
+func getList(key string, def []string) []string {
+	var list []string
+	for _, item := range strings.Split(viper.GetString(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, strings.ToLower(item))
+		}
+	}
+	if len(list) == 0 {
+		return def
+	}
+	return list
+}
+
+func without(list []string, item string) []string {
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		if v != item {
+			out = append(out, v)
+		}
 	}
+	return out
 }
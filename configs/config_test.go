@@ -2,7 +2,9 @@ package configs
 
 import (
 	"os"
+	"os/exec"
 	"testing"
+	"time"
 )
 
 func TestLoadConfig_WithEnvVars(t *testing.T) {
@@ -22,3 +24,87 @@ func TestLoadConfig_WithEnvVars(t *testing.T) {
 		t.Errorf("Expected OpenWeatherMapAPIKey to be 'test-openweather-key', got '%s'", config.OpenWeatherMapAPIKey)
 	}
 }
+
+func TestLoadConfig_CacheDefaults(t *testing.T) {
+	os.Setenv("WEATHERAPI_KEY", "test-weather-key")
+	os.Setenv("OPENWEATHERMAP_API_KEY", "test-openweather-key")
+	defer os.Unsetenv("WEATHERAPI_KEY")
+	defer os.Unsetenv("OPENWEATHERMAP_API_KEY")
+
+	config := LoadConfig()
+
+	if config.CEPCacheMaxEntries != 10000 {
+		t.Errorf("Expected CEPCacheMaxEntries to be 10000, got %d", config.CEPCacheMaxEntries)
+	}
+	if config.CEPCacheTTL != 24*time.Hour {
+		t.Errorf("Expected CEPCacheTTL to be 24h, got %s", config.CEPCacheTTL)
+	}
+	if config.CEPCacheNegativeTTL != 10*time.Minute {
+		t.Errorf("Expected CEPCacheNegativeTTL to be 10m, got %s", config.CEPCacheNegativeTTL)
+	}
+}
+
+func TestLoadConfig_CacheOverrides(t *testing.T) {
+	os.Setenv("WEATHERAPI_KEY", "test-weather-key")
+	os.Setenv("OPENWEATHERMAP_API_KEY", "test-openweather-key")
+	os.Setenv("CEP_CACHE_MAX_ENTRIES", "500")
+	os.Setenv("CEP_CACHE_TTL", "1h")
+	defer os.Unsetenv("WEATHERAPI_KEY")
+	defer os.Unsetenv("OPENWEATHERMAP_API_KEY")
+	defer os.Unsetenv("CEP_CACHE_MAX_ENTRIES")
+	defer os.Unsetenv("CEP_CACHE_TTL")
+
+	config := LoadConfig()
+
+	if config.CEPCacheMaxEntries != 500 {
+		t.Errorf("Expected CEPCacheMaxEntries to be 500, got %d", config.CEPCacheMaxEntries)
+	}
+	if config.CEPCacheTTL != time.Hour {
+		t.Errorf("Expected CEPCacheTTL to be 1h, got %s", config.CEPCacheTTL)
+	}
+}
+
+func TestLoadConfig_CEPProviders(t *testing.T) {
+	os.Setenv("WEATHERAPI_KEY", "test-weather-key")
+	os.Setenv("OPENWEATHERMAP_API_KEY", "test-openweather-key")
+	os.Setenv("CEP_PROVIDERS", " ViaCEP, brasilapi ,,")
+	defer os.Unsetenv("WEATHERAPI_KEY")
+	defer os.Unsetenv("OPENWEATHERMAP_API_KEY")
+	defer os.Unsetenv("CEP_PROVIDERS")
+
+	config := LoadConfig()
+
+	if len(config.CEPProviders) != 2 || config.CEPProviders[0] != "viacep" || config.CEPProviders[1] != "brasilapi" {
+		t.Errorf("Expected CEPProviders to be [viacep brasilapi], got %v", config.CEPProviders)
+	}
+}
+
+func TestLoadConfig_WithoutWeatherAPIKeyFallsBackToOpenMeteo(t *testing.T) {
+	os.Setenv("OPENWEATHERMAP_API_KEY", "test-openweather-key")
+	defer os.Unsetenv("OPENWEATHERMAP_API_KEY")
+
+	config := LoadConfig()
+
+	if len(config.WeatherProviders) != 1 || config.WeatherProviders[0] != "openmeteo" {
+		t.Errorf("Expected WeatherProviders to be [openmeteo], got %v", config.WeatherProviders)
+	}
+}
+
+func TestLoadConfig_RejectsInvalidBreakerFailureRatio(t *testing.T) {
+	if os.Getenv("LOAD_CONFIG_CRASH") == "1" {
+		LoadConfig()
+		return
+	}
+
+	for _, ratio := range []string{"0", "-0.5", "1.5"} {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestLoadConfig_RejectsInvalidBreakerFailureRatio$")
+		cmd.Env = append(os.Environ(),
+			"LOAD_CONFIG_CRASH=1",
+			"WEATHERAPI_KEY=test-weather-key",
+			"BREAKER_FAILURE_RATIO="+ratio,
+		)
+		if err := cmd.Run(); err == nil {
+			t.Fatalf("BREAKER_FAILURE_RATIO=%s: expected LoadConfig to exit", ratio)
+		}
+	}
+}
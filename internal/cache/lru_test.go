@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	c := NewLRU[string, int](2)
+	c.Set("a", 1, time.Minute)
+
+	v, ok := c.Get("a")
+	if !ok || v != 1 {
+		t.Fatalf("expected (1, true), got (%d, %v)", v, ok)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected miss for unknown key")
+	}
+}
+
+func TestLRU_Expiration(t *testing.T) {
+	now := time.Now()
+	c := NewLRU[string, int](0)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", 1, time.Minute)
+	now = now.Add(30 * time.Second)
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected hit before expiration")
+	}
+
+	now = now.Add(31 * time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected miss after expiration")
+	}
+	if c.Len() != 0 {
+		t.Fatalf("expected expired entry to be removed, len = %d", c.Len())
+	}
+}
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[string, int](2)
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Minute)
+
+	// Touch "a" so that "b" becomes the least recently used entry.
+	c.Get("a")
+	c.Set("c", 3, time.Minute)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a to be kept")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("expected c to be kept")
+	}
+}
+
+func TestLRU_ZeroTTLIsNotStored(t *testing.T) {
+	c := NewLRU[string, int](2)
+	c.Set("a", 1, 0)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected entry with zero TTL not to be stored")
+	}
+}
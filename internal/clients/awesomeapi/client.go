@@ -0,0 +1,74 @@
+package awesomeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+const providerName = "awesomeapi"
+
+const defaultBaseURL = "https://cep.awesomeapi.com.br"
+
+type client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewClient(httpClient *http.Client) viacep.Client {
+	return &client{httpClient: httpClient, baseURL: defaultBaseURL}
+}
+
+type cepResponse struct {
+	Cep      string `json:"cep"`
+	Address  string `json:"address"`
+	State    string `json:"state"`
+	District string `json:"district"`
+	City     string `json:"city"`
+	CityIBGE string `json:"city_ibge"`
+	DDD      string `json:"ddd"`
+	Lat      string `json:"lat"`
+	Lng      string `json:"lng"`
+}
+
+func (c *client) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/json/%s", c.baseURL, cep), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, clients.TransportError(providerName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, clients.StatusError(providerName, resp.StatusCode)
+	}
+
+	var cr cepResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
+	}
+
+	return &domain.ViaCEPAddress{
+		Cep:         cr.Cep,
+		Logradouro:  cr.Address,
+		Bairro:      cr.District,
+		Localidade:  cr.City,
+		Uf:          cr.State,
+		Ibge:        cr.CityIBGE,
+		Ddd:         cr.DDD,
+		Coordinates: domain.ParseCoordinates(cr.Lat, cr.Lng),
+	}, nil
+}
+
+func (c *client) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	return nil, clients.Unsupported(providerName)
+}
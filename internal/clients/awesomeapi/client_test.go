@@ -0,0 +1,46 @@
+package awesomeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+func TestClient_ConsultCEP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/json/01001000" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"cep":"01001000","address":"Praça da Sé","state":"SP","district":"Sé","lat":"-23.5479099","lng":"-46.636101","city":"São Paulo","city_ibge":"3550308","ddd":"11"}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	addr, err := c.ConsultCEP(context.Background(), "01001000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr.Localidade != "São Paulo" || addr.Uf != "SP" || addr.Ibge != "3550308" || addr.Logradouro != "Praça da Sé" {
+		t.Fatalf("unexpected address: %+v", addr)
+	}
+	if addr.Coordinates == nil || addr.Coordinates.Lat != -23.5479099 || addr.Coordinates.Lon != -46.636101 {
+		t.Fatalf("unexpected coordinates: %+v", addr.Coordinates)
+	}
+}
+
+func TestClient_ConsultCEP_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"not_found","message":"O CEP 99999999 nao foi encontrado"}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	if _, err := c.ConsultCEP(context.Background(), "99999999"); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package brasilapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+const providerName = "brasilapi"
+
+const defaultBaseURL = "https://brasilapi.com.br"
+
+type client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+func NewClient(httpClient *http.Client) viacep.Client {
+	return &client{httpClient: httpClient, baseURL: defaultBaseURL}
+}
+
+type cepResponse struct {
+	Cep          string `json:"cep"`
+	State        string `json:"state"`
+	City         string `json:"city"`
+	Neighborhood string `json:"neighborhood"`
+	Street       string `json:"street"`
+	Location     struct {
+		Coordinates struct {
+			Latitude  string `json:"latitude"`
+			Longitude string `json:"longitude"`
+		} `json:"coordinates"`
+	} `json:"location"`
+}
+
+func (c *client) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/cep/v2/%s", c.baseURL, cep), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, clients.TransportError(providerName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, clients.StatusError(providerName, resp.StatusCode)
+	}
+
+	var cr cepResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
+	}
+
+	return &domain.ViaCEPAddress{
+		Cep:         cr.Cep,
+		Logradouro:  cr.Street,
+		Bairro:      cr.Neighborhood,
+		Localidade:  cr.City,
+		Uf:          cr.State,
+		Coordinates: domain.ParseCoordinates(cr.Location.Coordinates.Latitude, cr.Location.Coordinates.Longitude),
+	}, nil
+}
+
+func (c *client) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	return nil, clients.Unsupported(providerName)
+}
@@ -0,0 +1,62 @@
+package brasilapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+func TestClient_ConsultCEP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/cep/v2/01001000" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"cep":"01001000","state":"SP","city":"São Paulo","neighborhood":"Sé","street":"Praça da Sé","service":"open-cep","location":{"type":"Point","coordinates":{"longitude":"-46.6339","latitude":"-23.5503"}}}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	addr, err := c.ConsultCEP(context.Background(), "01001000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr.Localidade != "São Paulo" || addr.Uf != "SP" || addr.Bairro != "Sé" || addr.Logradouro != "Praça da Sé" {
+		t.Fatalf("unexpected address: %+v", addr)
+	}
+	if addr.Coordinates == nil || addr.Coordinates.Lat != -23.5503 || addr.Coordinates.Lon != -46.6339 {
+		t.Fatalf("unexpected coordinates: %+v", addr.Coordinates)
+	}
+}
+
+func TestClient_ConsultCEP_WithoutCoordinates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"cep":"69900970","state":"AC","city":"Rio Branco","neighborhood":"","street":"","service":"correios","location":{"type":"Point","coordinates":{}}}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	addr, err := c.ConsultCEP(context.Background(), "69900970")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr.Localidade != "Rio Branco" || addr.Coordinates != nil {
+		t.Fatalf("unexpected address: %+v", addr)
+	}
+}
+
+func TestClient_ConsultCEP_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"name":"CepPromiseError","message":"Todos os serviços de CEP retornaram erro.","type":"service_error"}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	if _, err := c.ConsultCEP(context.Background(), "99999999"); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
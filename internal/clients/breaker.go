@@ -0,0 +1,218 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the provider while its circuit
+// breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type BreakerState string
+
+const (
+	StateClosed   BreakerState = "closed"
+	StateOpen     BreakerState = "open"
+	StateHalfOpen BreakerState = "half-open"
+)
+
+type BreakerConfig struct {
+	// FailureRatio opens the breaker once the share of failed requests in the
+	// current window reaches it, provided MinRequests were made.
+	FailureRatio float64
+	MinRequests  int
+	Window       time.Duration
+	// CoolDown is how long the breaker stays open before letting a single
+	// probe request through.
+	CoolDown time.Duration
+}
+
+type BreakerStatus struct {
+	Name      string       `json:"name"`
+	State     BreakerState `json:"state"`
+	Requests  int          `json:"requests"`
+	Failures  int          `json:"failures"`
+	OpenedAt  *time.Time   `json:"opened_at,omitempty"`
+	ChangedAt time.Time    `json:"changed_at"`
+}
+
+// Ticket identifies an allowed request to Record. It carries the generation
+// of the breaker, which changes on every state transition, so results of
+// requests admitted before a transition are not mistaken for newer ones.
+type Ticket struct {
+	generation uint64
+}
+
+type Breaker struct {
+	name   string
+	config BreakerConfig
+	now    func() time.Time
+
+	mu          sync.Mutex
+	state       BreakerState
+	changedAt   time.Time
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+	probing     bool
+	generation  uint64
+}
+
+func NewBreaker(name string, config BreakerConfig) *Breaker {
+	now := time.Now()
+	return &Breaker{
+		name:        name,
+		config:      config,
+		now:         time.Now,
+		state:       StateClosed,
+		changedAt:   now,
+		windowStart: now,
+	}
+}
+
+// Allow reports whether a request may be sent, returning ErrCircuitOpen
+// otherwise. Every allowed request must be followed by a call to Record with
+// the returned ticket.
+func (b *Breaker) Allow() (Ticket, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	switch b.state {
+	case StateOpen:
+		if now.Sub(b.openedAt) < b.config.CoolDown {
+			return Ticket{}, ErrCircuitOpen
+		}
+		b.transition(StateHalfOpen, now)
+		b.probing = true
+	case StateHalfOpen:
+		if b.probing {
+			return Ticket{}, ErrCircuitOpen
+		}
+		b.probing = true
+	default:
+		if now.Sub(b.windowStart) >= b.config.Window {
+			b.resetWindow(now)
+		}
+	}
+	return Ticket{generation: b.generation}, nil
+}
+
+// Record reports the outcome of the request allowed with ticket. Results of
+// requests admitted before the last state transition are ignored: in the
+// half-open state only the probe decides whether the breaker closes.
+func (b *Breaker) Record(ticket Ticket, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ticket.generation != b.generation {
+		return
+	}
+	now := b.now()
+	switch b.state {
+	case StateHalfOpen:
+		b.probing = false
+		if success {
+			b.transition(StateClosed, now)
+			b.resetWindow(now)
+		} else {
+			b.open(now)
+		}
+	case StateClosed:
+		b.requests++
+		if !success {
+			b.failures++
+		}
+		if b.requests >= b.config.MinRequests &&
+			float64(b.failures)/float64(b.requests) >= b.config.FailureRatio {
+			b.open(now)
+		}
+	}
+}
+
+// abandon releases a request that ended without telling anything about the
+// provider's health, such as one canceled by the caller.
+func (b *Breaker) abandon(ticket Ticket) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ticket.generation == b.generation {
+		b.probing = false
+	}
+}
+
+func (b *Breaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s := BreakerStatus{
+		Name:      b.name,
+		State:     b.state,
+		Requests:  b.requests,
+		Failures:  b.failures,
+		ChangedAt: b.changedAt,
+	}
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		s.OpenedAt = &openedAt
+	}
+	return s
+}
+
+func (b *Breaker) open(now time.Time) {
+	b.openedAt = now
+	b.transition(StateOpen, now)
+}
+
+func (b *Breaker) transition(to BreakerState, now time.Time) {
+	if b.state == to {
+		return
+	}
+	log.Printf("circuit breaker %s: %s -> %s (%d/%d failed)", b.name, b.state, to, b.failures, b.requests)
+	b.state = to
+	b.changedAt = now
+	b.generation++
+}
+
+func (b *Breaker) resetWindow(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+type breakerTransport struct {
+	next    http.RoundTripper
+	breaker *Breaker
+}
+
+// NewBreakerTransport guards next with breaker. Connection errors and 5xx
+// responses count as failures; requests canceled by the caller are ignored.
+func NewBreakerTransport(next http.RoundTripper, breaker *Breaker) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &breakerTransport{next: next, breaker: breaker}
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ticket, err := t.breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	switch {
+	case err != nil && errors.Is(req.Context().Err(), context.Canceled):
+		t.breaker.abandon(ticket)
+	case err != nil:
+		t.breaker.Record(ticket, false)
+	default:
+		t.breaker.Record(ticket, resp.StatusCode < 500)
+	}
+	return resp, err
+}
@@ -0,0 +1,145 @@
+package clients
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestBreaker() (*Breaker, *time.Time) {
+	now := time.Now()
+	b := NewBreaker("test", BreakerConfig{FailureRatio: 0.5, MinRequests: 4, Window: time.Minute, CoolDown: 10 * time.Second})
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func record(b *Breaker, results ...bool) {
+	for _, ok := range results {
+		if ticket, err := b.Allow(); err == nil {
+			b.Record(ticket, ok)
+		}
+	}
+}
+
+func TestBreaker_OpensWhenFailureRatioIsReached(t *testing.T) {
+	b, _ := newTestBreaker()
+
+	record(b, true, false, false)
+	if b.Status().State != StateClosed {
+		t.Fatal("expected breaker to stay closed below MinRequests")
+	}
+
+	record(b, true)
+	if b.Status().State != StateOpen {
+		t.Fatalf("expected breaker to open, got %s", b.Status().State)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+}
+
+func TestBreaker_WindowResetsCounters(t *testing.T) {
+	b, now := newTestBreaker()
+
+	record(b, false, false, true)
+	*now = now.Add(2 * time.Minute)
+	record(b, false)
+
+	if b.Status().State != StateClosed {
+		t.Fatal("expected failures from the previous window to be discarded")
+	}
+}
+
+func TestBreaker_HalfOpenAllowsSingleProbe(t *testing.T) {
+	b, now := newTestBreaker()
+	record(b, false, false, false, false)
+
+	*now = now.Add(11 * time.Second)
+	probe, err := b.Allow()
+	if err != nil {
+		t.Fatalf("expected probe to be allowed after cool-down, got %v", err)
+	}
+	if b.Status().State != StateHalfOpen {
+		t.Fatalf("expected half-open, got %s", b.Status().State)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("expected only one probe in half-open state")
+	}
+
+	b.Record(probe, true)
+	if b.Status().State != StateClosed {
+		t.Fatalf("expected successful probe to close the breaker, got %s", b.Status().State)
+	}
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	b, now := newTestBreaker()
+	record(b, false, false, false, false)
+
+	*now = now.Add(11 * time.Second)
+	record(b, false)
+	if b.Status().State != StateOpen {
+		t.Fatalf("expected failed probe to reopen the breaker, got %s", b.Status().State)
+	}
+}
+
+func TestBreaker_HalfOpenIgnoresStaleResults(t *testing.T) {
+	b, now := newTestBreaker()
+	late, _ := b.Allow()
+	record(b, false, false, false, false)
+
+	*now = now.Add(11 * time.Second)
+	probe, err := b.Allow()
+	if err != nil {
+		t.Fatalf("expected probe to be allowed after cool-down, got %v", err)
+	}
+
+	// A request admitted while the breaker was closed finishes during the
+	// probe and must not decide the breaker's state.
+	b.Record(late, true)
+	if b.Status().State != StateHalfOpen {
+		t.Fatalf("expected stale result to be ignored, got %s", b.Status().State)
+	}
+	b.abandon(late)
+	if _, err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatal("expected stale abandon not to release the probe")
+	}
+
+	b.Record(probe, false)
+	if b.Status().State != StateOpen {
+		t.Fatalf("expected failed probe to reopen the breaker, got %s", b.Status().State)
+	}
+}
+
+func TestBreakerTransport_FailsFastWhenOpen(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	b := NewBreaker("test", BreakerConfig{FailureRatio: 0.5, MinRequests: 2, Window: time.Minute, CoolDown: time.Minute})
+	c := &http.Client{Transport: NewBreakerTransport(http.DefaultTransport, b)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	_, err := c.Get(srv.URL)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if !errors.Is(TransportError("test", err), ErrUnavailable) {
+		t.Fatal("expected open circuit to be classified as unavailable")
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 upstream calls, got %d", calls)
+	}
+}
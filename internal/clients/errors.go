@@ -0,0 +1,91 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrUnavailable    = errors.New("upstream unavailable")
+	ErrRateLimited    = errors.New("upstream rate limited")
+	ErrBadCredentials = errors.New("upstream rejected credentials")
+	ErrDecode         = errors.New("invalid upstream response")
+	ErrTimeout        = errors.New("upstream timeout")
+	ErrUnsupported    = errors.New("operation not supported by provider")
+)
+
+// Error describes a failed call to an upstream provider. Kind is one of the
+// sentinel errors above and can be checked with errors.Is.
+type Error struct {
+	Provider   string
+	StatusCode int
+	Kind       error
+	Err        error
+}
+
+func (e *Error) Error() string {
+	msg := e.Provider + ": " + e.Kind.Error()
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status %d)", e.StatusCode)
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+// NotFound reports that the provider answered but has no data for the query.
+// err may carry a more specific reason and may be nil.
+func NotFound(provider string, err error) error {
+	return &Error{Provider: provider, Kind: ErrNotFound, Err: err}
+}
+
+// Unsupported reports that the provider does not offer the operation.
+func Unsupported(provider string) error {
+	return &Error{Provider: provider, Kind: ErrUnsupported}
+}
+
+// StatusError classifies a non-successful HTTP status returned by provider.
+func StatusError(provider string, status int) error {
+	var kind error
+	switch {
+	case status == http.StatusNotFound, status == http.StatusBadRequest:
+		kind = ErrNotFound
+	case status == http.StatusUnauthorized, status == http.StatusForbidden:
+		kind = ErrBadCredentials
+	case status == http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case status == http.StatusRequestTimeout, status == http.StatusGatewayTimeout:
+		kind = ErrTimeout
+	default:
+		kind = ErrUnavailable
+	}
+	return &Error{Provider: provider, StatusCode: status, Kind: kind}
+}
+
+// TransportError classifies an error returned while sending a request or
+// reading its response.
+func TransportError(provider string, err error) error {
+	kind := ErrUnavailable
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		kind = ErrTimeout
+	}
+	return &Error{Provider: provider, Kind: kind, Err: err}
+}
+
+// DecodeError reports a response body that could not be understood.
+func DecodeError(provider string, status int, err error) error {
+	return &Error{Provider: provider, StatusCode: status, Kind: ErrDecode, Err: err}
+}
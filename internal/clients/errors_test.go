@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		status int
+		kind   error
+	}{
+		{status: http.StatusNotFound, kind: ErrNotFound},
+		{status: http.StatusBadRequest, kind: ErrNotFound},
+		{status: http.StatusUnauthorized, kind: ErrBadCredentials},
+		{status: http.StatusForbidden, kind: ErrBadCredentials},
+		{status: http.StatusTooManyRequests, kind: ErrRateLimited},
+		{status: http.StatusGatewayTimeout, kind: ErrTimeout},
+		{status: http.StatusInternalServerError, kind: ErrUnavailable},
+		{status: http.StatusServiceUnavailable, kind: ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			err := StatusError("provider", tt.status)
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("StatusError(%d) = %v, expected kind %v", tt.status, err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	if err := TransportError("provider", fmt.Errorf("get: %w", context.DeadlineExceeded)); !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+	if err := TransportError("provider", errors.New("connection refused")); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("expected ErrUnavailable, got %v", err)
+	}
+}
+
+func TestError_WrapsCause(t *testing.T) {
+	cause := errors.New("no candidate in state")
+	err := NotFound("provider", cause)
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, cause) {
+		t.Fatalf("expected error to match both kind and cause, got %v", err)
+	}
+	if err.Error() != "provider: not found: no candidate in state" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}
@@ -0,0 +1,197 @@
+// Command gen builds municipios.csv, the municipality dataset embedded by the
+// ibge package. Codes, names and states come from the IBGE localidades API;
+// coordinates come from the municipios-brasileiros table, which holds the
+// location of each municipal seat and is joined by IBGE code.
+//
+// Run it through go generate from the ibge package directory:
+//
+//	go generate ./internal/clients/ibge
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/ibge"
+)
+
+const (
+	defaultMunicipiosURL  = "https://servicodados.ibge.gov.br/api/v1/localidades/municipios"
+	defaultCoordinatesURL = "https://raw.githubusercontent.com/kelvins/municipios-brasileiros/main/csv/municipios.csv"
+)
+
+type uf struct {
+	Sigla string `json:"sigla"`
+}
+
+// municipio is the subset of the localidades payload used here. Municipalities
+// created after the 2017 regional division have no microrregiao, so the state
+// is read from the regiao-imediata hierarchy first.
+type municipio struct {
+	ID             int    `json:"id"`
+	Nome           string `json:"nome"`
+	RegiaoImediata *struct {
+		RegiaoIntermediaria struct {
+			UF uf `json:"UF"`
+		} `json:"regiao-intermediaria"`
+	} `json:"regiao-imediata"`
+	Microrregiao *struct {
+		Mesorregiao struct {
+			UF uf `json:"UF"`
+		} `json:"mesorregiao"`
+	} `json:"microrregiao"`
+}
+
+func (m municipio) uf() string {
+	if m.RegiaoImediata != nil && m.RegiaoImediata.RegiaoIntermediaria.UF.Sigla != "" {
+		return m.RegiaoImediata.RegiaoIntermediaria.UF.Sigla
+	}
+	if m.Microrregiao != nil {
+		return m.Microrregiao.Mesorregiao.UF.Sigla
+	}
+	return ""
+}
+
+type coordinates struct {
+	lat, lon float64
+}
+
+func main() {
+	out := flag.String("o", "municipios.csv", "output file")
+	municipiosURL := flag.String("municipios", defaultMunicipiosURL, "IBGE localidades endpoint listing the municipalities")
+	coordinatesURL := flag.String("coordinates", defaultCoordinatesURL, "CSV with codigo_ibge, latitude and longitude columns")
+	flag.Parse()
+
+	httpClient := &http.Client{Timeout: time.Minute}
+
+	municipios, err := fetchMunicipios(httpClient, *municipiosURL)
+	if err != nil {
+		log.Fatalf("fetching municipalities: %v", err)
+	}
+	// A truncated list would make the geocoder report itself as incomplete.
+	if len(municipios) < ibge.MunicipalityCount {
+		log.Fatalf("expected at least %d municipalities, got %d", ibge.MunicipalityCount, len(municipios))
+	}
+
+	coords, err := fetchCoordinates(httpClient, *coordinatesURL)
+	if err != nil {
+		log.Fatalf("fetching coordinates: %v", err)
+	}
+
+	if err := write(*out, municipios, coords); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("wrote %d municipalities to %s", len(municipios), *out)
+}
+
+func fetchMunicipios(httpClient *http.Client, url string) ([]municipio, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var municipios []municipio
+	if err := json.NewDecoder(resp.Body).Decode(&municipios); err != nil {
+		return nil, err
+	}
+	sort.Slice(municipios, func(i, j int) bool { return municipios[i].ID < municipios[j].ID })
+	return municipios, nil
+}
+
+func fetchCoordinates(httpClient *http.Client, url string) (map[string]coordinates, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	records, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty coordinates file")
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[name] = i
+	}
+	for _, name := range []string{"codigo_ibge", "latitude", "longitude"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("coordinates file has no %s column", name)
+		}
+	}
+
+	coords := make(map[string]coordinates, len(records)-1)
+	for i, rec := range records[1:] {
+		lat, err := strconv.ParseFloat(rec[columns["latitude"]], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid latitude: %w", i+2, err)
+		}
+		lon, err := strconv.ParseFloat(rec[columns["longitude"]], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid longitude: %w", i+2, err)
+		}
+		coords[rec[columns["codigo_ibge"]]] = coordinates{lat: lat, lon: lon}
+	}
+	return coords, nil
+}
+
+// write fails when a municipality has no state or no coordinates instead of
+// leaving it out, so the dataset is never silently incomplete.
+func write(path string, municipios []municipio, coords map[string]coordinates) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"codigo_ibge", "nome", "uf", "latitude", "longitude"}); err != nil {
+		return err
+	}
+	for _, m := range municipios {
+		code := strconv.Itoa(m.ID)
+		uf := m.uf()
+		if uf == "" {
+			return fmt.Errorf("municipality %s (%s) has no state", code, m.Nome)
+		}
+		c, ok := coords[code]
+		if !ok {
+			return fmt.Errorf("municipality %s (%s/%s) has no coordinates", code, m.Nome, uf)
+		}
+		err := w.Write([]string{
+			code,
+			m.Nome,
+			uf,
+			strconv.FormatFloat(c.lat, 'f', -1, 64),
+			strconv.FormatFloat(c.lon, 'f', -1, 64),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
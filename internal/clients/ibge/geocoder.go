@@ -0,0 +1,197 @@
+package ibge
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/utils"
+)
+
+const providerName = "ibge"
+
+// MunicipalityCount is the number of municipalities listed by IBGE, the size
+// of a complete dataset.
+const MunicipalityCount = 5570
+
+// maxCentroidDistanceKm bounds the offline reverse lookup: farther than this
+// the nearest municipality of the dataset is unlikely to contain the point.
+const maxCentroidDistanceKm = 25
+
+// municipiosCSV holds the IBGE code, name, UF and centroid of Brazilian
+// municipalities. It is built by the generator in ./gen.
+//
+//go:generate go run ./gen -o municipios.csv
+//go:embed municipios.csv
+var municipiosCSV []byte
+
+type Municipality struct {
+	Code string
+	Name string
+	Uf   string
+	Lat  float64
+	Lon  float64
+}
+
+type Geocoder struct {
+	byCode   map[string]Municipality
+	byName   map[string][]Municipality
+	complete bool
+	fallback openweathermap.Client
+}
+
+// NewGeocoder returns a geocoder backed by the embedded municipality dataset.
+// Lookups that the dataset cannot answer are delegated to fallback, which may
+// be nil.
+func NewGeocoder(fallback openweathermap.Client) *Geocoder {
+	g, err := newGeocoder(bytes.NewReader(municipiosCSV), fallback)
+	if err != nil {
+		panic(fmt.Sprintf("ibge: invalid embedded dataset: %v", err))
+	}
+	return g
+}
+
+func newGeocoder(r io.Reader, fallback openweathermap.Client) (*Geocoder, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Geocoder{
+		byCode:   make(map[string]Municipality, len(records)),
+		byName:   make(map[string][]Municipality, len(records)),
+		fallback: fallback,
+	}
+	for i, rec := range records {
+		if i == 0 {
+			continue // header
+		}
+		if len(rec) != 5 {
+			return nil, fmt.Errorf("line %d: expected 5 columns, got %d", i+1, len(rec))
+		}
+		lat, err := strconv.ParseFloat(rec[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid latitude: %w", i+1, err)
+		}
+		lon, err := strconv.ParseFloat(rec[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid longitude: %w", i+1, err)
+		}
+
+		m := Municipality{Code: rec[0], Name: rec[1], Uf: rec[2], Lat: lat, Lon: lon}
+		g.byCode[m.Code] = m
+		key := utils.NormalizeName(m.Name)
+		g.byName[key] = append(g.byName[key], m)
+	}
+	g.complete = len(g.byCode) >= MunicipalityCount
+	return g, nil
+}
+
+// Complete reports whether the dataset lists every municipality, so that
+// lookups can be answered without a fallback.
+func (g *Geocoder) Complete() bool {
+	return g.complete
+}
+
+func (g *Geocoder) GetCoordinatesByIBGE(ctx context.Context, ibgeCode string) (*openweathermap.GeoLocation, error) {
+	m, ok := g.byCode[strings.TrimSpace(ibgeCode)]
+	if !ok {
+		return nil, clients.NotFound(providerName, fmt.Errorf("ibge code %s not in dataset", ibgeCode))
+	}
+	return toGeoLocation(m), nil
+}
+
+// GetCoordinates looks the city up by name in the dataset. When stateCode is
+// given only a municipality of that state is accepted, so homonymous cities
+// are not mixed up.
+func (g *Geocoder) GetCoordinates(ctx context.Context, cityName, stateCode, countryCode string) (*openweathermap.GeoLocation, error) {
+	if countryCode == "" || strings.EqualFold(countryCode, "BR") {
+		for _, m := range g.byName[utils.NormalizeName(cityName)] {
+			if stateCode == "" || strings.EqualFold(m.Uf, stateCode) {
+				return toGeoLocation(m), nil
+			}
+		}
+	}
+
+	if g.fallback == nil {
+		return nil, clients.NotFound(providerName, nil)
+	}
+	return g.fallback.GetCoordinates(ctx, cityName, stateCode, countryCode)
+}
+
+// ReverseGeocode resolves the municipality of the coordinates through
+// fallback, adding the IBGE code when the municipality is in the dataset.
+// Without fallback, or when it is unavailable, the nearest municipality
+// centroid of the dataset is used, but only if the dataset is complete:
+// otherwise the nearest centroid may belong to a neighbour of a missing
+// municipality.
+func (g *Geocoder) ReverseGeocode(ctx context.Context, lat, lon float64) (*openweathermap.GeoLocation, error) {
+	err := clients.NotFound(providerName, errors.New("reverse geocoding needs the complete dataset"))
+	if g.fallback != nil {
+		var loc *openweathermap.GeoLocation
+		loc, err = g.fallback.ReverseGeocode(ctx, lat, lon)
+		if err == nil {
+			g.fillIBGE(loc)
+			return loc, nil
+		}
+		if errors.Is(err, clients.ErrNotFound) {
+			return nil, err
+		}
+	}
+	if !g.complete {
+		return nil, err
+	}
+
+	var nearest *Municipality
+	best := math.Inf(1)
+	for _, m := range g.byCode {
+		if d := distanceKm(lat, lon, m.Lat, m.Lon); d < best {
+			nearest, best = &m, d
+		}
+	}
+	if nearest == nil || best > maxCentroidDistanceKm {
+		return nil, clients.NotFound(providerName, fmt.Errorf("no municipality within %d km", maxCentroidDistanceKm))
+	}
+	return toGeoLocation(*nearest), nil
+}
+
+func (g *Geocoder) fillIBGE(loc *openweathermap.GeoLocation) {
+	uf := domain.StateCode(loc.State)
+	for _, m := range g.byName[utils.NormalizeName(loc.Name)] {
+		if m.Uf == uf {
+			loc.IBGE = m.Code
+			return
+		}
+	}
+}
+
+// distanceKm is the haversine distance between two points.
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371
+	rad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat, dLon := rad(lat2-lat1), rad(lon2-lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+func toGeoLocation(m Municipality) *openweathermap.GeoLocation {
+	return &openweathermap.GeoLocation{
+		Name:    m.Name,
+		Lat:     m.Lat,
+		Lon:     m.Lon,
+		Country: "BR",
+		State:   domain.StateName(m.Uf),
+		IBGE:    m.Code,
+	}
+}
@@ -0,0 +1,171 @@
+package ibge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+)
+
+type stubGeoClient struct {
+	calls      int
+	reverse    *openweathermap.GeoLocation
+	reverseErr error
+}
+
+func (s *stubGeoClient) GetCoordinates(ctx context.Context, cityName, stateCode, countryCode string) (*openweathermap.GeoLocation, error) {
+	s.calls++
+	return &openweathermap.GeoLocation{Name: cityName, Lat: 1, Lon: 2}, nil
+}
+
+func (s *stubGeoClient) ReverseGeocode(ctx context.Context, lat, lon float64) (*openweathermap.GeoLocation, error) {
+	s.calls++
+	return s.reverse, s.reverseErr
+}
+
+func TestNewGeocoder_LoadsEmbeddedDataset(t *testing.T) {
+	g := NewGeocoder(nil)
+
+	loc, err := g.GetCoordinatesByIBGE(context.Background(), "3550308")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loc.Name != "São Paulo" || loc.State != "São Paulo" || loc.Country != "BR" {
+		t.Fatalf("unexpected location: %+v", loc)
+	}
+}
+
+func TestGeocoder_GetCoordinatesByIBGE_NotFound(t *testing.T) {
+	g := NewGeocoder(nil)
+
+	if _, err := g.GetCoordinatesByIBGE(context.Background(), "0000000"); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGeocoder_GetCoordinates_ByNormalizedName(t *testing.T) {
+	fallback := &stubGeoClient{}
+	g := NewGeocoder(fallback)
+
+	loc, err := g.GetCoordinates(context.Background(), "SAO PAULO", "SP", "BR")
+	if err != nil || loc.Lat != -23.5329 {
+		t.Fatalf("unexpected result: %+v, %v", loc, err)
+	}
+	if fallback.calls != 0 {
+		t.Fatalf("expected fallback not to be called, got %d calls", fallback.calls)
+	}
+}
+
+func TestGeocoder_GetCoordinates_UsesFallbackForUnknownCity(t *testing.T) {
+	fallback := &stubGeoClient{}
+	g := NewGeocoder(fallback)
+
+	loc, err := g.GetCoordinates(context.Background(), "Cidade Inexistente", "SP", "BR")
+	if err != nil || loc.Lat != 1 {
+		t.Fatalf("unexpected result: %+v, %v", loc, err)
+	}
+	if fallback.calls != 1 {
+		t.Fatalf("expected fallback to be called once, got %d calls", fallback.calls)
+	}
+}
+
+func TestGeocoder_Complete(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("codigo_ibge,nome,uf,latitude,longitude\n")
+	for i := 0; i < MunicipalityCount-1; i++ {
+		fmt.Fprintf(&b, "%d,Municipio %d,SP,-23.5,-46.6\n", 3500000+i, i)
+	}
+	g, err := newGeocoder(strings.NewReader(b.String()), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if g.Complete() {
+		t.Fatalf("expected dataset with %d municipalities to be incomplete", MunicipalityCount-1)
+	}
+
+	b.WriteString("5300108,Brasília,DF,-15.7795,-47.9297\n")
+	if g, _ = newGeocoder(strings.NewReader(b.String()), nil); !g.Complete() {
+		t.Fatal("expected dataset to be complete")
+	}
+}
+
+func TestNewGeocoder_InvalidDataset(t *testing.T) {
+	data := "codigo_ibge,nome,uf,latitude,longitude\n3550308,São Paulo,SP,abc,-46.6395\n"
+	if _, err := newGeocoder(strings.NewReader(data), nil); err == nil {
+		t.Fatal("expected error for invalid latitude")
+	}
+}
+
+func TestGeocoder_GetCoordinates_IgnoresCityFromOtherState(t *testing.T) {
+	fallback := &stubGeoClient{}
+	g := NewGeocoder(fallback)
+
+	// There is no "Campinas" in Goiás in the dataset, so the lookup must not
+	// return the one from São Paulo.
+	loc, err := g.GetCoordinates(context.Background(), "Campinas", "GO", "BR")
+	if err != nil || loc.Lat != 1 {
+		t.Fatalf("unexpected result: %+v, %v", loc, err)
+	}
+	if fallback.calls != 1 {
+		t.Fatalf("expected fallback to be called once, got %d calls", fallback.calls)
+	}
+}
+
+func TestGeocoder_ReverseGeocode_AddsIBGECodeToFallbackResult(t *testing.T) {
+	fallback := &stubGeoClient{reverse: &openweathermap.GeoLocation{Name: "Campinas", State: "São Paulo", Lat: -22.9, Lon: -47.06}}
+	g := NewGeocoder(fallback)
+
+	loc, err := g.ReverseGeocode(context.Background(), -22.91, -47.07)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loc.Name != "Campinas" || loc.IBGE != "3509502" {
+		t.Fatalf("unexpected location: %+v", loc)
+	}
+}
+
+func TestGeocoder_ReverseGeocode_NearestCentroidWithoutFallback(t *testing.T) {
+	g := NewGeocoder(nil)
+	g.complete = true
+
+	loc, err := g.ReverseGeocode(context.Background(), -23.56, -46.65)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loc.IBGE != "3550308" {
+		t.Fatalf("expected São Paulo, got %+v", loc)
+	}
+
+	if _, err := g.ReverseGeocode(context.Background(), 0, -30); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound far from any municipality, got %v", err)
+	}
+}
+
+func TestGeocoder_ReverseGeocode_FallbackUnavailable(t *testing.T) {
+	fallback := &stubGeoClient{reverseErr: clients.StatusError("openweathermap", 503)}
+	g := NewGeocoder(fallback)
+	g.complete = true
+
+	loc, err := g.ReverseGeocode(context.Background(), -23.56, -46.65)
+	if err != nil || loc.IBGE != "3550308" {
+		t.Fatalf("expected the dataset to answer, got %+v, %v", loc, err)
+	}
+}
+
+func TestGeocoder_ReverseGeocode_IncompleteDatasetDoesNotGuess(t *testing.T) {
+	g := NewGeocoder(nil)
+	g.complete = false
+
+	if _, err := g.ReverseGeocode(context.Background(), -23.56, -46.65); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	g.fallback = &stubGeoClient{reverseErr: clients.StatusError("openweathermap", 503)}
+	if _, err := g.ReverseGeocode(context.Background(), -23.56, -46.65); !errors.Is(err, clients.ErrUnavailable) {
+		t.Fatalf("expected the fallback error, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package opencep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+const providerName = "opencep"
+
+const defaultBaseURL = "https://opencep.com"
+
+type client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewClient returns a client for OpenCEP, whose responses follow the ViaCEP
+// format.
+func NewClient(httpClient *http.Client) viacep.Client {
+	return &client{httpClient: httpClient, baseURL: defaultBaseURL}
+}
+
+func (c *client) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s", c.baseURL, cep), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, clients.TransportError(providerName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, clients.StatusError(providerName, resp.StatusCode)
+	}
+
+	var addr domain.ViaCEPAddress
+	if err := json.NewDecoder(resp.Body).Decode(&addr); err != nil {
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
+	}
+	if addr.Erro {
+		return nil, clients.NotFound(providerName, nil)
+	}
+
+	return &addr, nil
+}
+
+func (c *client) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	return nil, clients.Unsupported(providerName)
+}
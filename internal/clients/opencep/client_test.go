@@ -0,0 +1,55 @@
+package opencep
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+func TestClient_ConsultCEP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/01001000" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"cep":"01001-000","logradouro":"Praça da Sé","complemento":"lado ímpar","bairro":"Sé","localidade":"São Paulo","uf":"SP","ibge":"3550308"}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	addr, err := c.ConsultCEP(context.Background(), "01001000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if addr.Localidade != "São Paulo" || addr.Uf != "SP" || addr.Ibge != "3550308" || addr.Logradouro != "Praça da Sé" {
+		t.Fatalf("unexpected address: %+v", addr)
+	}
+}
+
+func TestClient_ConsultCEP_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"CEP not found"}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	if _, err := c.ConsultCEP(context.Background(), "99999999"); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_ConsultCEP_ErroFlag(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"erro":true}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	if _, err := c.ConsultCEP(context.Background(), "99999999"); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
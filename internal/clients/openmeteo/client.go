@@ -0,0 +1,142 @@
+package openmeteo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/weatherapi"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+const providerName = "openmeteo"
+
+const defaultBaseURL = "https://api.open-meteo.com"
+
+const currentVariables = "temperature_2m,apparent_temperature,relative_humidity_2m,precipitation," +
+	"weather_code,cloud_cover,pressure_msl,wind_speed_10m,wind_direction_10m,uv_index"
+
+type client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewClient returns a weather client backed by the Open-Meteo forecast API,
+// which does not require an API key.
+func NewClient(httpClient *http.Client) weatherapi.Client {
+	return &client{httpClient: httpClient, baseURL: defaultBaseURL}
+}
+
+type currentResponse struct {
+	Current struct {
+		Time                string  `json:"time"`
+		Temperature2m       float64 `json:"temperature_2m"`
+		ApparentTemperature float64 `json:"apparent_temperature"`
+		RelativeHumidity2m  int     `json:"relative_humidity_2m"`
+		Precipitation       float64 `json:"precipitation"`
+		WeatherCode         int     `json:"weather_code"`
+		CloudCover          int     `json:"cloud_cover"`
+		PressureMsl         float64 `json:"pressure_msl"`
+		WindSpeed10m        float64 `json:"wind_speed_10m"`
+		WindDirection10m    int     `json:"wind_direction_10m"`
+		UVIndex             float64 `json:"uv_index"`
+	} `json:"current"`
+	Reason string `json:"reason"`
+}
+
+func (c *client) CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error) {
+	endpoint := fmt.Sprintf("%s/v1/forecast?latitude=%f&longitude=%f&current=%s&temperature_unit=celsius&wind_speed_unit=kmh&timezone=GMT",
+		c.baseURL, lat, lon, currentVariables)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, clients.TransportError(providerName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var cr currentResponse
+		if err := json.NewDecoder(resp.Body).Decode(&cr); err == nil && cr.Reason != "" {
+			return nil, fmt.Errorf("%w: %s", clients.StatusError(providerName, resp.StatusCode), cr.Reason)
+		}
+		return nil, clients.StatusError(providerName, resp.StatusCode)
+	}
+
+	var cr currentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
+	}
+
+	cur := cr.Current
+	lastUpdated, _ := time.Parse("2006-01-02T15:04", cur.Time)
+	return &domain.CurrentWeather{
+		TempC:       cur.Temperature2m,
+		FeelsLikeC:  cur.ApparentTemperature,
+		Humidity:    cur.RelativeHumidity2m,
+		WindKph:     cur.WindSpeed10m,
+		WindDegree:  cur.WindDirection10m,
+		WindDir:     compassDirection(cur.WindDirection10m),
+		PressureMb:  cur.PressureMsl,
+		PrecipMm:    cur.Precipitation,
+		UV:          cur.UVIndex,
+		Cloud:       cur.CloudCover,
+		Condition:   weatherCodeText(cur.WeatherCode),
+		LastUpdated: lastUpdated,
+	}, nil
+}
+
+var compassPoints = []string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+func compassDirection(degree int) string {
+	i := int(math.Round(float64(degree%360)/22.5)) % len(compassPoints)
+	return compassPoints[i]
+}
+
+// weatherCodeText describes a WMO weather interpretation code, as documented
+// at https://open-meteo.com/en/docs.
+func weatherCodeText(code int) string {
+	switch code {
+	case 0:
+		return "Clear sky"
+	case 1:
+		return "Mainly clear"
+	case 2:
+		return "Partly cloudy"
+	case 3:
+		return "Overcast"
+	case 45, 48:
+		return "Fog"
+	case 51, 53, 55:
+		return "Drizzle"
+	case 56, 57:
+		return "Freezing drizzle"
+	case 61, 63, 65:
+		return "Rain"
+	case 66, 67:
+		return "Freezing rain"
+	case 71, 73, 75, 77:
+		return "Snow"
+	case 80, 81, 82:
+		return "Rain showers"
+	case 85, 86:
+		return "Snow showers"
+	case 95:
+		return "Thunderstorm"
+	case 96, 99:
+		return "Thunderstorm with hail"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,80 @@
+package openmeteo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+func TestClient_CurrentByCoords(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v1/forecast" || q.Get("latitude") != "-23.550500" || q.Get("longitude") != "-46.633300" || !strings.Contains(q.Get("current"), "temperature_2m") {
+			t.Errorf("unexpected request %s", r.URL.String())
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"latitude": -23.5,
+			"longitude": -46.625,
+			"generationtime_ms": 0.02,
+			"utc_offset_seconds": 0,
+			"timezone": "GMT",
+			"timezone_abbreviation": "GMT",
+			"elevation": 760.0,
+			"current_units": {"time": "iso8601", "interval": "seconds", "temperature_2m": "°C"},
+			"current": {
+				"time": "2025-01-15T15:00",
+				"interval": 900,
+				"temperature_2m": 27.4,
+				"apparent_temperature": 29.8,
+				"relative_humidity_2m": 58,
+				"precipitation": 0.0,
+				"weather_code": 2,
+				"cloud_cover": 40,
+				"pressure_msl": 1013.2,
+				"wind_speed_10m": 9.4,
+				"wind_direction_10m": 135,
+				"uv_index": 6.5
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	current, err := c.CurrentByCoords(context.Background(), -23.5505, -46.6333)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.TempC != 27.4 || current.FeelsLikeC != 29.8 || current.Humidity != 58 {
+		t.Fatalf("unexpected conditions: %+v", current)
+	}
+	if current.WindDir != "SE" || current.Condition != "Partly cloudy" || current.UV != 6.5 {
+		t.Fatalf("unexpected conditions: %+v", current)
+	}
+	if !current.LastUpdated.Equal(time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected last_updated: %s", current.LastUpdated)
+	}
+}
+
+func TestClient_CurrentByCoords_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": true, "reason": "Latitude must be in range of -90 to 90°. Given: 200.0."}`))
+	}))
+	defer srv.Close()
+
+	c := &client{httpClient: srv.Client(), baseURL: srv.URL}
+	_, err := c.CurrentByCoords(context.Background(), 200, 0)
+	if !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "Latitude must be in range") {
+		t.Fatalf("expected error to carry the Open-Meteo reason, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/cache"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/utils"
+)
+
+type cachedClient struct {
+	next  Client
+	cache *cache.LRU[string, GeoLocation]
+	ttl   time.Duration
+}
+
+// NewCachedClient wraps next with an in-memory LRU cache keyed by the
+// normalized city, state and country, so every CEP of the same city shares a
+// single geocoding call. Reverse lookups share the cache, keyed by the
+// coordinates rounded to about 1 km. Failed lookups are never cached.
+func NewCachedClient(next Client, maxEntries int, ttl time.Duration) Client {
+	return &cachedClient{
+		next:  next,
+		cache: cache.NewLRU[string, GeoLocation](maxEntries),
+		ttl:   ttl,
+	}
+}
+
+func (c *cachedClient) GetCoordinates(ctx context.Context, cityName, stateCode, countryCode string) (*GeoLocation, error) {
+	key := cacheKey(cityName, stateCode, countryCode)
+	if loc, ok := c.cache.Get(key); ok {
+		return &loc, nil
+	}
+
+	loc, err := c.next.GetCoordinates(ctx, cityName, stateCode, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, *loc, c.ttl)
+
+	return loc, nil
+}
+
+func (c *cachedClient) ReverseGeocode(ctx context.Context, lat, lon float64) (*GeoLocation, error) {
+	key := reverseCacheKey(lat, lon)
+	if loc, ok := c.cache.Get(key); ok {
+		return &loc, nil
+	}
+
+	loc, err := c.next.ReverseGeocode(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, *loc, c.ttl)
+
+	return loc, nil
+}
+
+func reverseCacheKey(lat, lon float64) string {
+	return fmt.Sprintf("reverse|%.2f|%.2f", lat, lon)
+}
+
+func cacheKey(cityName, stateCode, countryCode string) string {
+	return utils.NormalizeName(cityName) + "|" + utils.NormalizeName(stateCode) + "|" + utils.NormalizeName(countryCode)
+}
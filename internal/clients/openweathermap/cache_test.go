@@ -0,0 +1,62 @@
+package openweathermap
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+type countingClient struct {
+	calls    int
+	location *GeoLocation
+	err      error
+}
+
+func (c *countingClient) GetCoordinates(ctx context.Context, cityName, stateCode, countryCode string) (*GeoLocation, error) {
+	c.calls++
+	return c.location, c.err
+}
+
+func (c *countingClient) ReverseGeocode(ctx context.Context, lat, lon float64) (*GeoLocation, error) {
+	c.calls++
+	return c.location, c.err
+}
+
+func TestCachedClient_SharesEntryForNormalizedCity(t *testing.T) {
+	next := &countingClient{location: &GeoLocation{Name: "São Paulo", Lat: -23.55, Lon: -46.63}}
+	c := NewCachedClient(next, 10, time.Hour)
+
+	for _, city := range []string{"São Paulo", "sao paulo", "  SÃO  PAULO "} {
+		loc, err := c.GetCoordinates(context.Background(), city, "SP", "BR")
+		if err != nil || loc.Lat != -23.55 {
+			t.Fatalf("unexpected result for %q: %+v, %v", city, loc, err)
+		}
+	}
+	if next.calls != 1 {
+		t.Fatalf("expected 1 upstream call, got %d", next.calls)
+	}
+}
+
+func TestCachedClient_DoesNotCacheFailures(t *testing.T) {
+	next := &countingClient{err: clients.StatusError("openweathermap", 401)}
+	c := NewCachedClient(next, 10, time.Hour)
+
+	c.GetCoordinates(context.Background(), "Campinas", "SP", "BR")
+	c.GetCoordinates(context.Background(), "Campinas", "SP", "BR")
+	if next.calls != 2 {
+		t.Fatalf("expected 2 upstream calls, got %d", next.calls)
+	}
+}
+
+func TestCachedClient_ReverseGeocodeBucketsNearbyCoordinates(t *testing.T) {
+	next := &countingClient{location: &GeoLocation{Name: "São Paulo"}}
+	c := NewCachedClient(next, 10, time.Hour)
+
+	c.ReverseGeocode(context.Background(), -23.5505, -46.6333)
+	c.ReverseGeocode(context.Background(), -23.5512, -46.6341)
+	if next.calls != 1 {
+		t.Fatalf("expected 1 upstream call, got %d", next.calls)
+	}
+}
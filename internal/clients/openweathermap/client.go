@@ -3,22 +3,41 @@ package openweathermap
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/utils"
 )
 
+// candidatesLimit is how many locations are requested when the state is
+// known, so homonymous cities from other states can be discarded.
+const candidatesLimit = 5
+
+const providerName = "openweathermap"
+
+const defaultBaseURL = "http://api.openweathermap.org"
+
+// ErrStateMismatch is wrapped in the clients.ErrNotFound returned when the
+// city exists but none of the candidates belongs to the requested state.
+var ErrStateMismatch = errors.New("no location found in the requested state")
+
 type Client interface {
-	GetCoordinates(ctx context.Context, cityName, countryCode string) (*GeoLocation, int, error)
+	GetCoordinates(ctx context.Context, cityName, stateCode, countryCode string) (*GeoLocation, error)
+	ReverseGeocode(ctx context.Context, lat, lon float64) (*GeoLocation, error)
 }
 
 type client struct {
 	httpClient *http.Client
 	apiKey     string
+	baseURL    string
 }
 
 func NewClient(httpClient *http.Client, apiKey string) Client {
-	return &client{httpClient: httpClient, apiKey: apiKey}
+	return &client{httpClient: httpClient, apiKey: apiKey, baseURL: defaultBaseURL}
 }
 
 type GeoLocation struct {
@@ -27,37 +46,88 @@ type GeoLocation struct {
 	Lon     float64 `json:"lon"`
 	Country string  `json:"country"`
 	State   string  `json:"state"`
+
+	// IBGE is the municipality code, only known to the IBGE geocoder.
+	IBGE string `json:"-"`
 }
 
-func (c *client) GetCoordinates(ctx context.Context, cityName, countryCode string) (*GeoLocation, int, error) {
+func (c *client) GetCoordinates(ctx context.Context, cityName, stateCode, countryCode string) (*GeoLocation, error) {
 	query := cityName
 	if countryCode != "" {
 		query = fmt.Sprintf("%s,%s", cityName, countryCode)
 	}
 
-	endpoint := fmt.Sprintf("http://api.openweathermap.org/geo/1.0/direct?q=%s&limit=1&appid=%s",
+	stateName := domain.StateName(stateCode)
+	limit := 1
+	if stateName != "" {
+		limit = candidatesLimit
+	}
+
+	endpoint := fmt.Sprintf("%s/geo/1.0/direct?q=%s&limit=%d&appid=%s",
+		c.baseURL,
 		url.QueryEscape(query),
+		limit,
 		url.QueryEscape(c.apiKey))
 
+	locations, err := c.fetch(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if stateName == "" {
+		return &locations[0], nil
+	}
+	for i := range locations {
+		if utils.NormalizeName(locations[i].State) == utils.NormalizeName(stateName) {
+			return &locations[i], nil
+		}
+	}
+	return nil, clients.NotFound(providerName, ErrStateMismatch)
+}
+
+// ReverseGeocode returns the municipality containing the coordinates.
+func (c *client) ReverseGeocode(ctx context.Context, lat, lon float64) (*GeoLocation, error) {
+	endpoint := fmt.Sprintf("%s/geo/1.0/reverse?lat=%f&lon=%f&limit=1&appid=%s",
+		c.baseURL, lat, lon, url.QueryEscape(c.apiKey))
+
+	locations, err := c.fetch(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return &locations[0], nil
+}
+
+// fetch calls a geocoding endpoint, which answers with a list of locations.
+// An empty list is reported as not found.
+func (c *client) fetch(ctx context.Context, endpoint string) ([]GeoLocation, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, 0, err
+		return nil, clients.TransportError(providerName, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 400 {
+		return nil, clients.StatusError(providerName, resp.StatusCode)
+	}
+
 	var locations []GeoLocation
 	if err := json.NewDecoder(resp.Body).Decode(&locations); err != nil {
-		return nil, resp.StatusCode, err
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
 	}
 
 	if len(locations) == 0 {
-		return nil, resp.StatusCode, fmt.Errorf("location not found")
+		return nil, clients.NotFound(providerName, nil)
 	}
+	return locations, nil
+}
 
-	return &locations[0], resp.StatusCode, nil
+// IBGEGeocoder is implemented by geocoders that can resolve a municipality
+// directly from the IBGE code returned by the CEP providers.
+type IBGEGeocoder interface {
+	GetCoordinatesByIBGE(ctx context.Context, ibgeCode string) (*GeoLocation, error)
 }
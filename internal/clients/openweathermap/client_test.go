@@ -0,0 +1,79 @@
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+const bomJesusCandidates = `[
+	{"name":"Bom Jesus","lat":-28.6683,"lon":-50.4297,"country":"BR","state":"Rio Grande do Sul"},
+	{"name":"Bom Jesus","lat":-9.0743,"lon":-44.3589,"country":"BR","state":"Piauí"},
+	{"name":"Bom Jesus","lat":-26.7328,"lon":-52.3919,"country":"BR","state":"Santa Catarina"}
+]`
+
+func newTestClient(t *testing.T, body string, wantLimit string) *client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("limit"); got != wantLimit {
+			t.Errorf("expected limit %s, got %s", wantLimit, got)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return &client{httpClient: srv.Client(), apiKey: "key", baseURL: srv.URL}
+}
+
+func TestClient_GetCoordinates_PicksCandidateFromState(t *testing.T) {
+	c := newTestClient(t, bomJesusCandidates, "5")
+
+	loc, err := c.GetCoordinates(context.Background(), "Bom Jesus", "PI", "BR")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loc.State != "Piauí" || loc.Lat != -9.0743 {
+		t.Fatalf("expected the Piauí candidate, got %+v", loc)
+	}
+}
+
+func TestClient_GetCoordinates_NoCandidateFromState(t *testing.T) {
+	c := newTestClient(t, bomJesusCandidates, "5")
+
+	_, err := c.GetCoordinates(context.Background(), "Bom Jesus", "GO", "BR")
+	if !errors.Is(err, ErrStateMismatch) || !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrStateMismatch wrapped in ErrNotFound, got %v", err)
+	}
+}
+
+func TestClient_GetCoordinates_WithoutState(t *testing.T) {
+	c := newTestClient(t, `[{"name":"Bom Jesus","lat":-28.6683,"lon":-50.4297,"country":"BR","state":"Rio Grande do Sul"}]`, "1")
+
+	loc, err := c.GetCoordinates(context.Background(), "Bom Jesus", "", "BR")
+	if err != nil || loc.State != "Rio Grande do Sul" {
+		t.Fatalf("unexpected result: %+v, %v", loc, err)
+	}
+}
+
+func TestClient_ReverseGeocode(t *testing.T) {
+	c := newTestClient(t, `[{"name":"Campinas","lat":-22.9056,"lon":-47.0608,"country":"BR","state":"São Paulo"}]`, "1")
+
+	loc, err := c.ReverseGeocode(context.Background(), -22.91, -47.06)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loc.Name != "Campinas" || loc.State != "São Paulo" {
+		t.Fatalf("unexpected location: %+v", loc)
+	}
+}
+
+func TestClient_ReverseGeocode_NotFound(t *testing.T) {
+	c := newTestClient(t, `[]`, "1")
+
+	if _, err := c.ReverseGeocode(context.Background(), 0, -30); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
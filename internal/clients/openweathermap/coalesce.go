@@ -0,0 +1,40 @@
+package openweathermap
+
+import (
+	"context"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/singleflight"
+)
+
+type coalescingClient struct {
+	next  Client
+	group singleflight.Group[string, *GeoLocation]
+}
+
+// NewCoalescingClient wraps next so that concurrent geocoding requests for
+// the same city share a single upstream request.
+func NewCoalescingClient(next Client) Client {
+	return &coalescingClient{next: next}
+}
+
+func (c *coalescingClient) GetCoordinates(ctx context.Context, cityName, stateCode, countryCode string) (*GeoLocation, error) {
+	shared, _, err := c.group.Do(ctx, cacheKey(cityName, stateCode, countryCode), func(ctx context.Context) (*GeoLocation, error) {
+		return c.next.GetCoordinates(ctx, cityName, stateCode, countryCode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	loc := *shared
+	return &loc, nil
+}
+
+func (c *coalescingClient) ReverseGeocode(ctx context.Context, lat, lon float64) (*GeoLocation, error) {
+	shared, _, err := c.group.Do(ctx, reverseCacheKey(lat, lon), func(ctx context.Context) (*GeoLocation, error) {
+		return c.next.ReverseGeocode(ctx, lat, lon)
+	})
+	if err != nil {
+		return nil, err
+	}
+	loc := *shared
+	return &loc, nil
+}
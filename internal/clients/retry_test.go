@@ -0,0 +1,123 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryServer(t *testing.T, statuses ...int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(calls.Add(1))
+		status := statuses[min(n, len(statuses))-1]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func retryClient(config RetryConfig) *http.Client {
+	return &http.Client{Transport: NewRetryTransport(http.DefaultTransport, config)}
+}
+
+func TestRetryTransport_RetriesServerErrors(t *testing.T) {
+	srv, calls := newRetryServer(t, 503, 502, 200)
+	c := retryClient(RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 || calls.Load() != 3 {
+		t.Fatalf("expected success after 3 calls, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryTransport_RetriesRateLimit(t *testing.T) {
+	srv, calls := newRetryServer(t, 429, 200)
+	c := retryClient(RetryConfig{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+
+	// Retry-After: 0 must take precedence over the configured backoff.
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 || calls.Load() != 2 {
+		t.Fatalf("expected success after 2 calls, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryTransport_GivesUpAfterMaxAttempts(t *testing.T) {
+	srv, calls := newRetryServer(t, 500)
+	c := retryClient(RetryConfig{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 500 || calls.Load() != 2 {
+		t.Fatalf("expected 500 after 2 calls, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestRetryTransport_DoesNotRetryClientErrors(t *testing.T) {
+	srv, calls := newRetryServer(t, 404)
+	c := retryClient(RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+	resp, err := c.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestRetryTransport_DoesNotRetryNonIdempotentRequests(t *testing.T) {
+	srv, calls := newRetryServer(t, 503)
+	c := retryClient(RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+	resp, err := c.Post(srv.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 call, got %d", calls.Load())
+	}
+}
+
+func TestRetryTransport_RespectsContextDeadline(t *testing.T) {
+	srv, calls := newRetryServer(t, 503)
+	c := retryClient(RetryConfig{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+
+	start := time.Now()
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	// A one second backoff can not fit in a 50ms deadline, so the first
+	// response is returned right away instead of sleeping until the deadline.
+	if calls.Load() != 1 || time.Since(start) > 40*time.Millisecond {
+		t.Fatalf("expected a single quick attempt, got %d calls in %s", calls.Load(), time.Since(start))
+	}
+}
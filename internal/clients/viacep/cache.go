@@ -0,0 +1,63 @@
+package viacep
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/cache"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type cachedResult struct {
+	addr domain.ViaCEPAddress
+	err  error
+}
+
+type cachedClient struct {
+	next        Client
+	cache       *cache.LRU[string, cachedResult]
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+// NewCachedClient wraps next with an in-memory LRU cache. Found addresses are
+// kept for ttl and "not found" answers for negativeTTL. Any other error is
+// never cached.
+func NewCachedClient(next Client, maxEntries int, ttl, negativeTTL time.Duration) Client {
+	return &cachedClient{
+		next:        next,
+		cache:       cache.NewLRU[string, cachedResult](maxEntries),
+		ttl:         ttl,
+		negativeTTL: negativeTTL,
+	}
+}
+
+func (c *cachedClient) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	if res, ok := c.cache.Get(cep); ok {
+		if res.err != nil {
+			return nil, res.err
+		}
+		addr := res.addr
+		return &addr, nil
+	}
+
+	addr, err := c.next.ConsultCEP(ctx, cep)
+	if errors.Is(err, clients.ErrNotFound) {
+		c.cache.Set(cep, cachedResult{err: err}, c.negativeTTL)
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(cep, cachedResult{addr: *addr}, c.ttl)
+
+	return addr, nil
+}
+
+// SearchAddress is not cached: searches are rare and their results are
+// lists that would crowd out the CEP entries.
+func (c *cachedClient) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	return c.next.SearchAddress(ctx, uf, city, street)
+}
@@ -0,0 +1,71 @@
+package viacep
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type countingClient struct {
+	calls int
+	addr  *domain.ViaCEPAddress
+	addrs []domain.ViaCEPAddress
+	err   error
+}
+
+func (c *countingClient) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	c.calls++
+	return c.addr, c.err
+}
+
+func (c *countingClient) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	c.calls++
+	return c.addrs, c.err
+}
+
+func TestCachedClient_ServesRepeatedLookupsFromCache(t *testing.T) {
+	next := &countingClient{addr: &domain.ViaCEPAddress{Cep: "01153-000", Localidade: "São Paulo"}}
+	c := NewCachedClient(next, 10, time.Hour, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		addr, err := c.ConsultCEP(context.Background(), "01153000")
+		if err != nil || addr.Localidade != "São Paulo" {
+			t.Fatalf("unexpected result: %+v, %v", addr, err)
+		}
+	}
+	if next.calls != 1 {
+		t.Fatalf("expected 1 upstream call, got %d", next.calls)
+	}
+}
+
+func TestCachedClient_CachesNegativeResults(t *testing.T) {
+	next := &countingClient{err: clients.NotFound("viacep", nil)}
+	c := NewCachedClient(next, 10, time.Hour, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.ConsultCEP(context.Background(), "99999999"); !errors.Is(err, clients.ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+	if next.calls != 1 {
+		t.Fatalf("expected 1 upstream call, got %d", next.calls)
+	}
+}
+
+func TestCachedClient_DoesNotCacheErrors(t *testing.T) {
+	next := &countingClient{err: clients.StatusError("viacep", 503)}
+	c := NewCachedClient(next, 10, time.Hour, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.ConsultCEP(context.Background(), "01153000"); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+	if next.calls != 2 {
+		t.Fatalf("expected 2 upstream calls, got %d", next.calls)
+	}
+}
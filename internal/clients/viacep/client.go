@@ -5,38 +5,85 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
 	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
 )
 
+const providerName = "viacep"
+
+const defaultBaseURL = "https://viacep.com.br"
+
 type Client interface {
-	ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, int, error)
+	ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error)
+	// SearchAddress lists the CEPs of the streets of city matching street.
+	// Providers without address search return clients.ErrUnsupported.
+	SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error)
 }
 
 type client struct {
 	httpClient *http.Client
+	baseURL    string
 }
 
 func NewClient(httpClient *http.Client) Client {
-	return &client{httpClient: httpClient}
+	return &client{httpClient: httpClient, baseURL: defaultBaseURL}
 }
 
-func (c *client) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, int, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://viacep.com.br/ws/%s/json/", cep), nil)
+func (c *client) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/ws/%s/json/", c.baseURL, cep), nil)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, 0, err
+		return nil, clients.TransportError(providerName, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 400 {
+		return nil, clients.StatusError(providerName, resp.StatusCode)
+	}
+
 	var addr domain.ViaCEPAddress
 	if err := json.NewDecoder(resp.Body).Decode(&addr); err != nil {
-		return nil, resp.StatusCode, err
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
+	}
+	if addr.Erro {
+		return nil, clients.NotFound(providerName, nil)
+	}
+
+	return &addr, nil
+}
+
+func (c *client) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	endpoint := fmt.Sprintf("%s/ws/%s/%s/%s/json/",
+		c.baseURL, url.PathEscape(uf), url.PathEscape(city), url.PathEscape(street))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, clients.TransportError(providerName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, clients.StatusError(providerName, resp.StatusCode)
+	}
+
+	var addrs []domain.ViaCEPAddress
+	if err := json.NewDecoder(resp.Body).Decode(&addrs); err != nil {
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
+	}
+	if len(addrs) == 0 {
+		return nil, clients.NotFound(providerName, nil)
 	}
 
-	return &addr, resp.StatusCode, nil
+	return addrs, nil
 }
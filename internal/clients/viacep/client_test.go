@@ -0,0 +1,48 @@
+package viacep
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+func newTestClient(t *testing.T, body string) (*client, *string) {
+	t.Helper()
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath()
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return &client{httpClient: srv.Client(), baseURL: srv.URL}, &path
+}
+
+func TestClient_SearchAddress(t *testing.T) {
+	c, path := newTestClient(t, `[
+		{"cep": "91420-270", "logradouro": "Rua Domingos José Poli", "bairro": "Jardim Carvalho", "localidade": "Porto Alegre", "uf": "RS", "ibge": "4314902"},
+		{"cep": "91040-000", "logradouro": "Rua Domingos Rubbo", "bairro": "Cristo Redentor", "localidade": "Porto Alegre", "uf": "RS", "ibge": "4314902"}
+	]`)
+
+	addrs, err := c.SearchAddress(context.Background(), "RS", "Porto Alegre", "Domingos")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(addrs) != 2 || addrs[1].Cep != "91040-000" || addrs[0].Ibge != "4314902" {
+		t.Fatalf("unexpected addresses: %+v", addrs)
+	}
+	if *path != "/ws/RS/Porto%20Alegre/Domingos/json/" {
+		t.Fatalf("unexpected path %q", *path)
+	}
+}
+
+func TestClient_SearchAddress_NoResults(t *testing.T) {
+	c, _ := newTestClient(t, `[]`)
+
+	if _, err := c.SearchAddress(context.Background(), "RS", "Porto Alegre", "Inexistente"); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
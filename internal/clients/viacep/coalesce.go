@@ -0,0 +1,43 @@
+package viacep
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/singleflight"
+)
+
+type coalescingClient struct {
+	next     Client
+	group    singleflight.Group[string, *domain.ViaCEPAddress]
+	searches singleflight.Group[string, []domain.ViaCEPAddress]
+}
+
+// NewCoalescingClient wraps next so that concurrent lookups for the same CEP
+// share a single upstream request.
+func NewCoalescingClient(next Client) Client {
+	return &coalescingClient{next: next}
+}
+
+func (c *coalescingClient) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	shared, _, err := c.group.Do(ctx, cep, func(ctx context.Context) (*domain.ViaCEPAddress, error) {
+		return c.next.ConsultCEP(ctx, cep)
+	})
+	if err != nil {
+		return nil, err
+	}
+	addr := *shared
+	return &addr, nil
+}
+
+func (c *coalescingClient) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	key := strings.Join([]string{uf, city, street}, "|")
+	shared, _, err := c.searches.Do(ctx, key, func(ctx context.Context) ([]domain.ViaCEPAddress, error) {
+		return c.next.SearchAddress(ctx, uf, city, street)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]domain.ViaCEPAddress(nil), shared...), nil
+}
@@ -0,0 +1,51 @@
+package viacep
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type blockingClient struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (c *blockingClient) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	c.calls.Add(1)
+	<-c.release
+	return &domain.ViaCEPAddress{Cep: cep, Localidade: "São Paulo"}, nil
+}
+
+func (c *blockingClient) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	return nil, nil
+}
+
+func TestCoalescingClient_SharesInFlightLookup(t *testing.T) {
+	next := &blockingClient{release: make(chan struct{})}
+	c := NewCoalescingClient(next)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			addr, err := c.ConsultCEP(context.Background(), "01153000")
+			if err != nil || addr.Localidade != "São Paulo" {
+				t.Errorf("unexpected result: %+v, %v", addr, err)
+			}
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	if next.calls.Load() != 1 {
+		t.Fatalf("expected 1 upstream call, got %d", next.calls.Load())
+	}
+}
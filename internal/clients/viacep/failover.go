@@ -0,0 +1,101 @@
+package viacep
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+// Provider is a named CEP lookup implementation used by the failover client.
+type Provider struct {
+	Name   string
+	Client Client
+}
+
+type failoverClient struct {
+	providers      []Provider
+	attemptTimeout time.Duration
+}
+
+// NewFailoverClient tries each provider in order, moving on to the next one
+// when a provider fails for any reason other than clients.ErrNotFound, which
+// is a definitive answer. attemptTimeout bounds each individual attempt so
+// that a hanging provider leaves time for the others; zero means attempts are
+// only bounded by ctx.
+func NewFailoverClient(attemptTimeout time.Duration, providers ...Provider) Client {
+	return &failoverClient{providers: providers, attemptTimeout: attemptTimeout}
+}
+
+func (c *failoverClient) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	err := errors.New("no cep provider configured")
+
+	for _, p := range c.providers {
+		if ctx.Err() != nil {
+			break
+		}
+
+		var addr *domain.ViaCEPAddress
+		addr, err = c.attempt(ctx, p, cep)
+		if err == nil || errors.Is(err, clients.ErrNotFound) {
+			return addr, err
+		}
+		log.Printf("cep provider %s failed for %s: %v", p.Name, cep, err)
+	}
+
+	return nil, err
+}
+
+// SearchAddress follows the same rules as ConsultCEP, skipping providers that
+// do not support address search. clients.ErrUnsupported is only returned when
+// no provider supports it; otherwise the last real failure is reported.
+func (c *failoverClient) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	var err, unsupported error
+
+	for _, p := range c.providers {
+		if ctx.Err() != nil {
+			break
+		}
+
+		addrs, attemptErr := c.attemptSearch(ctx, p, uf, city, street)
+		if attemptErr == nil || errors.Is(attemptErr, clients.ErrNotFound) {
+			return addrs, attemptErr
+		}
+		if errors.Is(attemptErr, clients.ErrUnsupported) {
+			unsupported = attemptErr
+			continue
+		}
+		log.Printf("cep provider %s failed to search %s/%s/%s: %v", p.Name, uf, city, street, attemptErr)
+		err = attemptErr
+	}
+
+	switch {
+	case err != nil:
+		return nil, err
+	case unsupported != nil:
+		return nil, unsupported
+	default:
+		return nil, errors.New("no cep provider configured")
+	}
+}
+
+func (c *failoverClient) attemptSearch(ctx context.Context, p Provider, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	if c.attemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.attemptTimeout)
+		defer cancel()
+	}
+	return p.Client.SearchAddress(ctx, uf, city, street)
+}
+
+func (c *failoverClient) attempt(ctx context.Context, p Provider, cep string) (*domain.ViaCEPAddress, error) {
+	if c.attemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.attemptTimeout)
+		defer cancel()
+	}
+	return p.Client.ConsultCEP(ctx, cep)
+}
@@ -0,0 +1,119 @@
+package viacep
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type slowClient struct{}
+
+func (slowClient) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	<-ctx.Done()
+	return nil, clients.TransportError("slow", ctx.Err())
+}
+
+func (slowClient) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	<-ctx.Done()
+	return nil, clients.TransportError("slow", ctx.Err())
+}
+
+func TestFailoverClient_FallsBackOnError(t *testing.T) {
+	primary := &countingClient{err: clients.TransportError("primary", errors.New("connection reset"))}
+	secondary := &countingClient{addr: &domain.ViaCEPAddress{Localidade: "São Paulo"}}
+	c := NewFailoverClient(0, Provider{Name: "primary", Client: primary}, Provider{Name: "secondary", Client: secondary})
+
+	addr, err := c.ConsultCEP(context.Background(), "01153000")
+	if err != nil || addr.Localidade != "São Paulo" {
+		t.Fatalf("unexpected result: %+v, %v", addr, err)
+	}
+	if primary.calls != 1 || secondary.calls != 1 {
+		t.Fatalf("expected one call per provider, got %d and %d", primary.calls, secondary.calls)
+	}
+}
+
+func TestFailoverClient_FallsBackOnServerError(t *testing.T) {
+	primary := &countingClient{err: clients.StatusError("primary", 503)}
+	secondary := &countingClient{addr: &domain.ViaCEPAddress{Localidade: "Campinas"}}
+	c := NewFailoverClient(0, Provider{Name: "primary", Client: primary}, Provider{Name: "secondary", Client: secondary})
+
+	addr, err := c.ConsultCEP(context.Background(), "13010000")
+	if err != nil || addr.Localidade != "Campinas" {
+		t.Fatalf("unexpected result: %+v, %v", addr, err)
+	}
+}
+
+func TestFailoverClient_FallsBackOnTimeout(t *testing.T) {
+	secondary := &countingClient{addr: &domain.ViaCEPAddress{Localidade: "Campinas"}}
+	c := NewFailoverClient(20*time.Millisecond, Provider{Name: "slow", Client: slowClient{}}, Provider{Name: "secondary", Client: secondary})
+
+	addr, err := c.ConsultCEP(context.Background(), "13010000")
+	if err != nil || addr.Localidade != "Campinas" {
+		t.Fatalf("unexpected result: %+v, %v", addr, err)
+	}
+}
+
+func TestFailoverClient_DoesNotFallBackOnNotFound(t *testing.T) {
+	primary := &countingClient{err: clients.NotFound("primary", nil)}
+	secondary := &countingClient{addr: &domain.ViaCEPAddress{Localidade: "Campinas"}}
+	c := NewFailoverClient(0, Provider{Name: "primary", Client: primary}, Provider{Name: "secondary", Client: secondary})
+
+	if _, err := c.ConsultCEP(context.Background(), "99999999"); !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if secondary.calls != 0 {
+		t.Fatalf("expected secondary not to be called, got %d calls", secondary.calls)
+	}
+}
+
+func TestFailoverClient_ReturnsLastErrorWhenAllFail(t *testing.T) {
+	lastErr := clients.StatusError("secondary", 502)
+	c := NewFailoverClient(0,
+		Provider{Name: "primary", Client: &countingClient{err: clients.StatusError("primary", 500)}},
+		Provider{Name: "secondary", Client: &countingClient{err: lastErr}},
+	)
+
+	if _, err := c.ConsultCEP(context.Background(), "01153000"); err != lastErr {
+		t.Fatalf("expected last provider error, got %v", err)
+	}
+}
+
+func TestFailoverClient_SearchSkipsUnsupportedProviders(t *testing.T) {
+	unsupported := &countingClient{err: clients.Unsupported("brasilapi")}
+	viaCEP := &countingClient{addrs: []domain.ViaCEPAddress{{Cep: "91420-270"}, {Cep: "91420-271"}}}
+	c := NewFailoverClient(0, Provider{Name: "brasilapi", Client: unsupported}, Provider{Name: "viacep", Client: viaCEP})
+
+	addrs, err := c.SearchAddress(context.Background(), "RS", "Porto Alegre", "Domingos")
+	if err != nil || len(addrs) != 2 {
+		t.Fatalf("unexpected result: %+v, %v", addrs, err)
+	}
+}
+
+func TestFailoverClient_SearchUnsupportedByAllProviders(t *testing.T) {
+	c := NewFailoverClient(0, Provider{Name: "brasilapi", Client: &countingClient{err: clients.Unsupported("brasilapi")}})
+
+	if _, err := c.SearchAddress(context.Background(), "RS", "Porto Alegre", "Domingos"); !errors.Is(err, clients.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestFailoverClient_SearchKeepsErrorOfSupportingProvider(t *testing.T) {
+	viaCEPErr := clients.StatusError("viacep", 503)
+	c := NewFailoverClient(0,
+		Provider{Name: "viacep", Client: &countingClient{err: viaCEPErr}},
+		Provider{Name: "brasilapi", Client: &countingClient{err: clients.Unsupported("brasilapi")}},
+		Provider{Name: "opencep", Client: &countingClient{err: clients.Unsupported("opencep")}},
+	)
+
+	_, err := c.SearchAddress(context.Background(), "RS", "Porto Alegre", "Domingos")
+	if err != viaCEPErr {
+		t.Fatalf("expected viacep error, got %v", err)
+	}
+	if errors.Is(err, clients.ErrUnsupported) {
+		t.Fatalf("expected a failure other than ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package weatherapi
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/cache"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type cachedClient struct {
+	next      Client
+	cache     *cache.LRU[string, domain.CurrentWeather]
+	ttl       time.Duration
+	precision int
+}
+
+// NewCachedClient wraps next with a short-lived cache. Coordinates are
+// rounded to precision decimal places before being used as the key, so nearby
+// points (about 1.1 km apart with precision 2) share the same entry.
+func NewCachedClient(next Client, maxEntries int, ttl time.Duration, precision int) Client {
+	return &cachedClient{
+		next:      next,
+		cache:     cache.NewLRU[string, domain.CurrentWeather](maxEntries),
+		ttl:       ttl,
+		precision: precision,
+	}
+}
+
+func (c *cachedClient) CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error) {
+	lat, lon = c.bucket(lat), c.bucket(lon)
+	key := strconv.FormatFloat(lat, 'f', c.precision, 64) + "," + strconv.FormatFloat(lon, 'f', c.precision, 64)
+	if current, ok := c.cache.Get(key); ok {
+		return &current, nil
+	}
+
+	current, err := c.next.CurrentByCoords(ctx, lat, lon)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, *current, c.ttl)
+
+	return current, nil
+}
+
+func (c *cachedClient) bucket(v float64) float64 {
+	p := math.Pow(10, float64(c.precision))
+	return math.Round(v*p) / p
+}
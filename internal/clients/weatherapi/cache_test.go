@@ -0,0 +1,51 @@
+package weatherapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type countingClient struct {
+	calls int
+	tempC float64
+	err   error
+}
+
+func (c *countingClient) CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &domain.CurrentWeather{TempC: c.tempC}, nil
+}
+
+func TestCachedClient_BucketsNearbyCoordinates(t *testing.T) {
+	next := &countingClient{tempC: 25}
+	c := NewCachedClient(next, 10, time.Minute, 2)
+
+	c.CurrentByCoords(context.Background(), -23.5505, -46.6333)
+	c.CurrentByCoords(context.Background(), -23.5512, -46.6341)
+	if next.calls != 1 {
+		t.Fatalf("expected nearby coordinates to share a cache entry, got %d calls", next.calls)
+	}
+
+	c.CurrentByCoords(context.Background(), -22.9068, -43.1729)
+	if next.calls != 2 {
+		t.Fatalf("expected distant coordinates to miss the cache, got %d calls", next.calls)
+	}
+}
+
+func TestCachedClient_DoesNotCacheFailures(t *testing.T) {
+	next := &countingClient{err: clients.StatusError("weatherapi", 503)}
+	c := NewCachedClient(next, 10, time.Minute, 2)
+
+	c.CurrentByCoords(context.Background(), -23.55, -46.63)
+	c.CurrentByCoords(context.Background(), -23.55, -46.63)
+	if next.calls != 2 {
+		t.Fatalf("expected 2 upstream calls, got %d", next.calls)
+	}
+}
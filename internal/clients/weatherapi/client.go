@@ -6,46 +6,116 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+const providerName = "weatherapi"
+
+const defaultBaseURL = "https://api.weatherapi.com"
+
+// Error codes documented at https://www.weatherapi.com/docs/#intro-error-codes
+const (
+	errCodeNoLocation    = 1006
+	errCodeQuotaExceeded = 2007
 )
 
 type Client interface {
-	CurrentTempCByCoords(ctx context.Context, lat, lon float64) (float64, int, error)
+	CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error)
 }
 
 type client struct {
 	httpClient *http.Client
 	apiKey     string
+	baseURL    string
 }
 
 func NewClient(httpClient *http.Client, apiKey string) Client {
-	return &client{httpClient: httpClient, apiKey: apiKey}
+	return &client{httpClient: httpClient, apiKey: apiKey, baseURL: defaultBaseURL}
 }
 
 type currentResponse struct {
 	Current struct {
-		TempC float64 `json:"temp_c"`
+		LastUpdatedEpoch int64   `json:"last_updated_epoch"`
+		TempC            float64 `json:"temp_c"`
+		FeelsLikeC       float64 `json:"feelslike_c"`
+		Humidity         int     `json:"humidity"`
+		WindKph          float64 `json:"wind_kph"`
+		WindDegree       int     `json:"wind_degree"`
+		WindDir          string  `json:"wind_dir"`
+		PressureMb       float64 `json:"pressure_mb"`
+		PrecipMm         float64 `json:"precip_mm"`
+		UV               float64 `json:"uv"`
+		Cloud            int     `json:"cloud"`
+		Condition        struct {
+			Text string `json:"text"`
+			Icon string `json:"icon"`
+		} `json:"condition"`
 	} `json:"current"`
 }
 
-func (c *client) CurrentTempCByCoords(ctx context.Context, lat, lon float64) (float64, int, error) {
-	endpoint := fmt.Sprintf("https://api.weatherapi.com/v1/current.json?key=%s&q=%f,%f",
-		url.QueryEscape(c.apiKey), lat, lon)
+type errorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c *client) CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error) {
+	endpoint := fmt.Sprintf("%s/v1/current.json?key=%s&q=%f,%f",
+		c.baseURL, url.QueryEscape(c.apiKey), lat, lon)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
-		return 0, 0, err
+		return nil, err
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return 0, 0, err
+		return nil, clients.TransportError(providerName, err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode >= 400 {
+		return nil, statusError(resp)
+	}
+
 	var cr currentResponse
 	if err := json.NewDecoder(resp.Body).Decode(&cr); err != nil {
-		return 0, resp.StatusCode, err
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
 	}
 
-	return cr.Current.TempC, resp.StatusCode, nil
+	cur := cr.Current
+	return &domain.CurrentWeather{
+		TempC:         cur.TempC,
+		FeelsLikeC:    cur.FeelsLikeC,
+		Humidity:      cur.Humidity,
+		WindKph:       cur.WindKph,
+		WindDegree:    cur.WindDegree,
+		WindDir:       cur.WindDir,
+		PressureMb:    cur.PressureMb,
+		PrecipMm:      cur.PrecipMm,
+		UV:            cur.UV,
+		Cloud:         cur.Cloud,
+		Condition:     cur.Condition.Text,
+		ConditionIcon: cur.Condition.Icon,
+		LastUpdated:   time.Unix(cur.LastUpdatedEpoch, 0).UTC(),
+	}, nil
+}
+
+// statusError refines the HTTP status with the error code WeatherAPI sends
+// in the body, since it answers 400 and 403 for unrelated situations.
+func statusError(resp *http.Response) error {
+	var er errorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&er); err == nil {
+		switch er.Error.Code {
+		case errCodeNoLocation:
+			return clients.NotFound(providerName, fmt.Errorf("%s", er.Error.Message))
+		case errCodeQuotaExceeded:
+			return &clients.Error{Provider: providerName, StatusCode: resp.StatusCode, Kind: clients.ErrRateLimited}
+		}
+	}
+	return clients.StatusError(providerName, resp.StatusCode)
 }
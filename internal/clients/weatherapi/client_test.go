@@ -0,0 +1,80 @@
+package weatherapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+func newTestClient(t *testing.T, status int, body string) *client {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return &client{httpClient: srv.Client(), apiKey: "key", baseURL: srv.URL}
+}
+
+func TestClient_CurrentByCoords(t *testing.T) {
+	c := newTestClient(t, http.StatusOK, `{
+		"location": {"name": "Sao Paulo", "region": "Sao Paulo", "country": "Brazil"},
+		"current": {
+			"last_updated_epoch": 1736953200,
+			"last_updated": "2025-01-15 12:00",
+			"temp_c": 28.5,
+			"condition": {"text": "Partly cloudy", "icon": "//cdn.weatherapi.com/weather/64x64/day/116.png", "code": 1003},
+			"wind_kph": 11.2,
+			"wind_degree": 140,
+			"wind_dir": "SE",
+			"pressure_mb": 1015.0,
+			"precip_mm": 0.1,
+			"humidity": 62,
+			"cloud": 50,
+			"feelslike_c": 30.1,
+			"uv": 7.0
+		}
+	}`)
+
+	current, err := c.CurrentByCoords(context.Background(), -23.55, -46.63)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if current.TempC != 28.5 || current.FeelsLikeC != 30.1 || current.Humidity != 62 || current.WindDir != "SE" {
+		t.Fatalf("unexpected conditions: %+v", current)
+	}
+	if current.Condition != "Partly cloudy" || current.UV != 7 || current.Cloud != 50 {
+		t.Fatalf("unexpected conditions: %+v", current)
+	}
+	if !current.LastUpdated.Equal(time.Unix(1736953200, 0)) {
+		t.Fatalf("unexpected last_updated: %s", current.LastUpdated)
+	}
+}
+
+func TestClient_CurrentByCoords_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		kind   error
+	}{
+		{name: "No matching location", status: 400, body: `{"error":{"code":1006,"message":"No matching location found."}}`, kind: clients.ErrNotFound},
+		{name: "Quota exceeded", status: 403, body: `{"error":{"code":2007,"message":"API key has exceeded calls per month quota."}}`, kind: clients.ErrRateLimited},
+		{name: "Invalid key", status: 401, body: `{"error":{"code":2006,"message":"API key provided is invalid"}}`, kind: clients.ErrBadCredentials},
+		{name: "Server error", status: 500, body: `internal error`, kind: clients.ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, tt.status, tt.body)
+			if _, err := c.CurrentByCoords(context.Background(), 0, 0); !errors.Is(err, tt.kind) {
+				t.Fatalf("expected %v, got %v", tt.kind, err)
+			}
+		})
+	}
+}
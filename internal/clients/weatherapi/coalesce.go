@@ -0,0 +1,32 @@
+package weatherapi
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/singleflight"
+)
+
+type coalescingClient struct {
+	next  Client
+	group singleflight.Group[string, *domain.CurrentWeather]
+}
+
+// NewCoalescingClient wraps next so that concurrent requests for the same
+// coordinates share a single upstream request.
+func NewCoalescingClient(next Client) Client {
+	return &coalescingClient{next: next}
+}
+
+func (c *coalescingClient) CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error) {
+	key := strconv.FormatFloat(lat, 'f', -1, 64) + "," + strconv.FormatFloat(lon, 'f', -1, 64)
+	shared, _, err := c.group.Do(ctx, key, func(ctx context.Context) (*domain.CurrentWeather, error) {
+		return c.next.CurrentByCoords(ctx, lat, lon)
+	})
+	if err != nil {
+		return nil, err
+	}
+	current := *shared
+	return &current, nil
+}
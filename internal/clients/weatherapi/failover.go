@@ -0,0 +1,58 @@
+package weatherapi
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+// Provider is a named weather implementation used by the failover client.
+type Provider struct {
+	Name   string
+	Client Client
+}
+
+type failoverClient struct {
+	providers      []Provider
+	attemptTimeout time.Duration
+}
+
+// NewFailoverClient tries each provider in order until one of them answers
+// successfully, so an outage or an exhausted API key on one provider does not
+// fail the request. attemptTimeout bounds each individual attempt so that a
+// hanging provider leaves time for the others; zero means attempts are only
+// bounded by ctx.
+func NewFailoverClient(attemptTimeout time.Duration, providers ...Provider) Client {
+	return &failoverClient{providers: providers, attemptTimeout: attemptTimeout}
+}
+
+func (c *failoverClient) CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error) {
+	err := errors.New("no weather provider configured")
+
+	for _, p := range c.providers {
+		if ctx.Err() != nil {
+			break
+		}
+
+		var current *domain.CurrentWeather
+		current, err = c.attempt(ctx, p, lat, lon)
+		if err == nil {
+			return current, nil
+		}
+		log.Printf("weather provider %s failed for %f,%f: %v", p.Name, lat, lon, err)
+	}
+
+	return nil, err
+}
+
+func (c *failoverClient) attempt(ctx context.Context, p Provider, lat, lon float64) (*domain.CurrentWeather, error) {
+	if c.attemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.attemptTimeout)
+		defer cancel()
+	}
+	return p.Client.CurrentByCoords(ctx, lat, lon)
+}
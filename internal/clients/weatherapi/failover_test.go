@@ -0,0 +1,62 @@
+package weatherapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type slowClient struct{}
+
+func (slowClient) CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error) {
+	<-ctx.Done()
+	return nil, clients.TransportError("slow", ctx.Err())
+}
+
+func TestFailoverClient_FallsBackOnFailure(t *testing.T) {
+	primary := &countingClient{err: clients.StatusError("weatherapi", 429)}
+	secondary := &countingClient{tempC: 21.5}
+	c := NewFailoverClient(0, Provider{Name: "primary", Client: primary}, Provider{Name: "secondary", Client: secondary})
+
+	current, err := c.CurrentByCoords(context.Background(), -23.55, -46.63)
+	if err != nil || current.TempC != 21.5 {
+		t.Fatalf("unexpected result: %+v, %v", current, err)
+	}
+}
+
+func TestFailoverClient_StopsAtFirstSuccess(t *testing.T) {
+	primary := &countingClient{tempC: 25}
+	secondary := &countingClient{tempC: 21.5}
+	c := NewFailoverClient(0, Provider{Name: "primary", Client: primary}, Provider{Name: "secondary", Client: secondary})
+
+	c.CurrentByCoords(context.Background(), -23.55, -46.63)
+	if secondary.calls != 0 {
+		t.Fatalf("expected secondary not to be called, got %d calls", secondary.calls)
+	}
+}
+
+func TestFailoverClient_ReturnsLastErrorWhenAllFail(t *testing.T) {
+	lastErr := errors.New("secondary down")
+	c := NewFailoverClient(0,
+		Provider{Name: "primary", Client: &countingClient{err: errors.New("primary down")}},
+		Provider{Name: "secondary", Client: &countingClient{err: lastErr}},
+	)
+
+	if _, err := c.CurrentByCoords(context.Background(), -23.55, -46.63); err != lastErr {
+		t.Fatalf("expected last provider error, got %v", err)
+	}
+}
+
+func TestFailoverClient_FallsBackOnTimeout(t *testing.T) {
+	secondary := &countingClient{tempC: 21.5}
+	c := NewFailoverClient(20*time.Millisecond, Provider{Name: "slow", Client: slowClient{}}, Provider{Name: "secondary", Client: secondary})
+
+	current, err := c.CurrentByCoords(context.Background(), -23.55, -46.63)
+	if err != nil || current.TempC != 21.5 {
+		t.Fatalf("unexpected result: %+v, %v", current, err)
+	}
+}
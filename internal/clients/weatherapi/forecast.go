@@ -0,0 +1,114 @@
+package weatherapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+// MaxForecastDays is the longest forecast WeatherAPI serves.
+const MaxForecastDays = 14
+
+// ForecastClient is implemented only by WeatherAPI: the other weather
+// providers are used for current conditions.
+type ForecastClient interface {
+	ForecastByCoords(ctx context.Context, lat, lon float64, days int) ([]domain.DailyWeather, error)
+}
+
+func NewForecastClient(httpClient *http.Client, apiKey string) ForecastClient {
+	return &client{httpClient: httpClient, apiKey: apiKey, baseURL: defaultBaseURL}
+}
+
+type forecastCondition struct {
+	Text string `json:"text"`
+}
+
+type forecastResponse struct {
+	Forecast struct {
+		ForecastDay []struct {
+			Date string `json:"date"`
+			Day  struct {
+				MaxTempC          float64           `json:"maxtemp_c"`
+				MinTempC          float64           `json:"mintemp_c"`
+				AvgTempC          float64           `json:"avgtemp_c"`
+				TotalPrecipMm     float64           `json:"totalprecip_mm"`
+				AvgHumidity       float64           `json:"avghumidity"`
+				DailyChanceOfRain int               `json:"daily_chance_of_rain"`
+				Condition         forecastCondition `json:"condition"`
+			} `json:"day"`
+			Hour []struct {
+				TimeEpoch    int64             `json:"time_epoch"`
+				TempC        float64           `json:"temp_c"`
+				ChanceOfRain int               `json:"chance_of_rain"`
+				PrecipMm     float64           `json:"precip_mm"`
+				Humidity     int               `json:"humidity"`
+				WindKph      float64           `json:"wind_kph"`
+				Condition    forecastCondition `json:"condition"`
+			} `json:"hour"`
+		} `json:"forecastday"`
+	} `json:"forecast"`
+}
+
+func (c *client) ForecastByCoords(ctx context.Context, lat, lon float64, days int) ([]domain.DailyWeather, error) {
+	endpoint := fmt.Sprintf("%s/v1/forecast.json?key=%s&q=%f,%f&days=%d&aqi=no&alerts=no",
+		c.baseURL, url.QueryEscape(c.apiKey), lat, lon, days)
+	return c.daily(ctx, endpoint)
+}
+
+// daily fetches an endpoint answering with the forecastday layout, shared by
+// the forecast and history APIs.
+func (c *client) daily(ctx context.Context, endpoint string) ([]domain.DailyWeather, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, clients.TransportError(providerName, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, statusError(resp)
+	}
+
+	var fr forecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&fr); err != nil {
+		return nil, clients.DecodeError(providerName, resp.StatusCode, err)
+	}
+
+	result := make([]domain.DailyWeather, 0, len(fr.Forecast.ForecastDay))
+	for _, fd := range fr.Forecast.ForecastDay {
+		day := domain.DailyWeather{
+			Date:          fd.Date,
+			MinTempC:      fd.Day.MinTempC,
+			MaxTempC:      fd.Day.MaxTempC,
+			AvgTempC:      fd.Day.AvgTempC,
+			ChanceOfRain:  fd.Day.DailyChanceOfRain,
+			TotalPrecipMm: fd.Day.TotalPrecipMm,
+			AvgHumidity:   fd.Day.AvgHumidity,
+			Condition:     fd.Day.Condition.Text,
+			Hours:         make([]domain.HourlyWeather, 0, len(fd.Hour)),
+		}
+		for _, h := range fd.Hour {
+			day.Hours = append(day.Hours, domain.HourlyWeather{
+				Time:         time.Unix(h.TimeEpoch, 0).UTC(),
+				TempC:        h.TempC,
+				ChanceOfRain: h.ChanceOfRain,
+				PrecipMm:     h.PrecipMm,
+				Humidity:     h.Humidity,
+				WindKph:      h.WindKph,
+				Condition:    h.Condition.Text,
+			})
+		}
+		result = append(result, day)
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package weatherapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+func TestClient_ForecastByCoords(t *testing.T) {
+	c := newTestClient(t, http.StatusOK, `{
+		"forecast": {"forecastday": [
+			{
+				"date": "2025-01-15",
+				"day": {"maxtemp_c": 31.2, "mintemp_c": 19.8, "avgtemp_c": 24.9, "totalprecip_mm": 12.3,
+					"avghumidity": 78, "daily_chance_of_rain": 89, "condition": {"text": "Patchy rain nearby"}},
+				"hour": [
+					{"time_epoch": 1736910000, "temp_c": 21.0, "chance_of_rain": 0, "precip_mm": 0, "humidity": 90, "wind_kph": 5.4, "condition": {"text": "Clear"}},
+					{"time_epoch": 1736913600, "temp_c": 20.6, "chance_of_rain": 70, "precip_mm": 0.4, "humidity": 92, "wind_kph": 6.1, "condition": {"text": "Light rain"}}
+				]
+			},
+			{"date": "2025-01-16", "day": {"maxtemp_c": 29.0, "mintemp_c": 20.1, "avgtemp_c": 23.7}, "hour": []}
+		]}
+	}`)
+
+	days, err := c.ForecastByCoords(context.Background(), -23.55, -46.63, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(days))
+	}
+	d := days[0]
+	if d.Date != "2025-01-15" || d.MaxTempC != 31.2 || d.MinTempC != 19.8 || d.ChanceOfRain != 89 {
+		t.Fatalf("unexpected day: %+v", d)
+	}
+	if len(d.Hours) != 2 || d.Hours[1].ChanceOfRain != 70 || !d.Hours[1].Time.Equal(time.Unix(1736913600, 0)) {
+		t.Fatalf("unexpected hours: %+v", d.Hours)
+	}
+}
+
+func TestClient_ForecastByCoords_NoLocation(t *testing.T) {
+	c := newTestClient(t, http.StatusBadRequest, `{"error": {"code": 1006, "message": "No matching location found."}}`)
+
+	_, err := c.ForecastByCoords(context.Background(), 0, 0, 3)
+	if !errors.Is(err, clients.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
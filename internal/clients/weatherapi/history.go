@@ -0,0 +1,35 @@
+package weatherapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+// MaxHistoryDays is the longest range WeatherAPI accepts in a single
+// history request.
+const MaxHistoryDays = 30
+
+const dateLayout = "2006-01-02"
+
+// HistoryClient returns the observed weather between two dates, inclusive.
+type HistoryClient interface {
+	HistoryByCoords(ctx context.Context, lat, lon float64, from, to time.Time) ([]domain.DailyWeather, error)
+}
+
+func NewHistoryClient(httpClient *http.Client, apiKey string) HistoryClient {
+	return &client{httpClient: httpClient, apiKey: apiKey, baseURL: defaultBaseURL}
+}
+
+func (c *client) HistoryByCoords(ctx context.Context, lat, lon float64, from, to time.Time) ([]domain.DailyWeather, error) {
+	endpoint := fmt.Sprintf("%s/v1/history.json?key=%s&q=%f,%f&dt=%s",
+		c.baseURL, url.QueryEscape(c.apiKey), lat, lon, from.Format(dateLayout))
+	if to.After(from) {
+		endpoint += "&end_dt=" + to.Format(dateLayout)
+	}
+	return c.daily(ctx, endpoint)
+}
@@ -0,0 +1,53 @@
+package weatherapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_HistoryByCoords(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"forecast": {"forecastday": [
+			{"date": "2025-01-10", "day": {"maxtemp_c": 30.1, "mintemp_c": 21.4, "avgtemp_c": 25.2, "totalprecip_mm": 8.2}},
+			{"date": "2025-01-11", "day": {"maxtemp_c": 27.9, "mintemp_c": 20.0, "avgtemp_c": 23.5}}
+		]}}`))
+	}))
+	defer srv.Close()
+	c := &client{httpClient: srv.Client(), apiKey: "key", baseURL: srv.URL}
+
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	days, err := c.HistoryByCoords(context.Background(), -23.55, -46.63, from, from.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(days) != 2 || days[0].TotalPrecipMm != 8.2 || days[1].Date != "2025-01-11" {
+		t.Fatalf("unexpected days: %+v", days)
+	}
+	want := "key=key&q=-23.550000,-46.630000&dt=2025-01-10&end_dt=2025-01-11"
+	if query != want {
+		t.Fatalf("unexpected query %q, want %q", query, want)
+	}
+}
+
+func TestClient_HistoryByCoords_SingleDay(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"forecast": {"forecastday": []}}`))
+	}))
+	defer srv.Close()
+	c := &client{httpClient: srv.Client(), apiKey: "key", baseURL: srv.URL}
+
+	day := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	if _, err := c.HistoryByCoords(context.Background(), 1, 2, day, day); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "key=key&q=1.000000,2.000000&dt=2025-01-10"; query != want {
+		t.Fatalf("unexpected query %q, want %q", query, want)
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseCoordinates builds Coordinates from the string latitude/longitude
+// pair some CEP providers return. It returns nil when either value is missing
+// or out of range.
+func ParseCoordinates(lat, lon string) *Coordinates {
+	la, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil || la < -90 || la > 90 {
+		return nil
+	}
+	lo, err := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if err != nil || lo < -180 || lo > 180 {
+		return nil
+	}
+	return &Coordinates{Lat: la, Lon: lo}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/utils"
+)
+
+var stateNames = map[string]string{
+	"AC": "Acre",
+	"AL": "Alagoas",
+	"AP": "Amapá",
+	"AM": "Amazonas",
+	"BA": "Bahia",
+	"CE": "Ceará",
+	"DF": "Distrito Federal",
+	"ES": "Espírito Santo",
+	"GO": "Goiás",
+	"MA": "Maranhão",
+	"MT": "Mato Grosso",
+	"MS": "Mato Grosso do Sul",
+	"MG": "Minas Gerais",
+	"PA": "Pará",
+	"PB": "Paraíba",
+	"PR": "Paraná",
+	"PE": "Pernambuco",
+	"PI": "Piauí",
+	"RJ": "Rio de Janeiro",
+	"RN": "Rio Grande do Norte",
+	"RS": "Rio Grande do Sul",
+	"RO": "Rondônia",
+	"RR": "Roraima",
+	"SC": "Santa Catarina",
+	"SP": "São Paulo",
+	"SE": "Sergipe",
+	"TO": "Tocantins",
+}
+
+// StateName returns the full name of a Brazilian state from its UF code, or
+// an empty string when the code is unknown.
+func StateName(uf string) string {
+	return stateNames[strings.ToUpper(strings.TrimSpace(uf))]
+}
+
+// StateCode returns the UF of a Brazilian state from its full name, ignoring
+// case and accents, or an empty string when the name is unknown.
+func StateCode(name string) string {
+	name = utils.NormalizeName(name)
+	for uf, stateName := range stateNames {
+		if utils.NormalizeName(stateName) == name {
+			return uf
+		}
+	}
+	if name == "federal district" {
+		return "DF"
+	}
+	return ""
+}
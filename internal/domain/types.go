@@ -1,9 +1,64 @@
 package domain
 
+import "time"
+
 type WeatherResponse struct {
 	TempC float64 `json:"temp_C"`
 	TempF float64 `json:"temp_F"`
 	TempK float64 `json:"temp_K"`
+
+	// Details is only filled when the caller asks for the detailed payload.
+	Details *WeatherDetails `json:"details,omitempty"`
+	// Location is only filled when the caller asks for it.
+	Location *WeatherLocation `json:"location,omitempty"`
+}
+
+// WeatherLocation describes the place the temperature was taken for and how
+// it was resolved. City, UF and IBGE are unknown when the caller sent
+// coordinates.
+type WeatherLocation struct {
+	City       string     `json:"city,omitempty"`
+	UF         string     `json:"uf,omitempty"`
+	IBGE       string     `json:"ibge,omitempty"`
+	Lat        float64    `json:"lat"`
+	Lon        float64    `json:"lon"`
+	Geocoder   string     `json:"geocoder"`
+	ObservedAt *time.Time `json:"observed_at,omitempty"`
+}
+
+type WeatherDetails struct {
+	FeelsLikeC    float64   `json:"feels_like_C"`
+	FeelsLikeF    float64   `json:"feels_like_F"`
+	FeelsLikeK    float64   `json:"feels_like_K"`
+	Humidity      int       `json:"humidity"`
+	WindKph       float64   `json:"wind_kph"`
+	WindDegree    int       `json:"wind_degree"`
+	WindDir       string    `json:"wind_dir,omitempty"`
+	PressureMb    float64   `json:"pressure_mb"`
+	PrecipMm      float64   `json:"precip_mm"`
+	UV            float64   `json:"uv"`
+	Cloud         int       `json:"cloud"`
+	Condition     string    `json:"condition,omitempty"`
+	ConditionIcon string    `json:"condition_icon,omitempty"`
+	LastUpdated   time.Time `json:"last_updated"`
+}
+
+// CurrentWeather holds the current conditions reported by a weather
+// provider. Providers that do not report a field leave it zero.
+type CurrentWeather struct {
+	TempC         float64
+	FeelsLikeC    float64
+	Humidity      int
+	WindKph       float64
+	WindDegree    int
+	WindDir       string
+	PressureMb    float64
+	PrecipMm      float64
+	UV            float64
+	Cloud         int
+	Condition     string
+	ConditionIcon string
+	LastUpdated   time.Time
 }
 
 type ViaCEPAddress struct {
@@ -18,4 +73,123 @@ type ViaCEPAddress struct {
 	Ddd         string `json:"ddd"`
 	Siafi       string `json:"siafi"`
 	Erro        bool   `json:"erro"`
+
+	// Coordinates is filled by providers that return the location of the
+	// CEP, allowing the geocoding step to be skipped.
+	Coordinates *Coordinates `json:"coordinates,omitempty"`
+}
+
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// Problem is an RFC 7807 problem details document.
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	CEP       string `json:"cep,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+type ForecastResponse struct {
+	Days []ForecastDay `json:"days"`
+}
+
+// HistoryResponse lists the observed weather of each day of the requested
+// range, using the same layout as the forecast.
+type HistoryResponse struct {
+	Days []ForecastDay `json:"days"`
+}
+
+type ForecastDay struct {
+	Date          string         `json:"date"`
+	MinTempC      float64        `json:"min_temp_C"`
+	MinTempF      float64        `json:"min_temp_F"`
+	MinTempK      float64        `json:"min_temp_K"`
+	MaxTempC      float64        `json:"max_temp_C"`
+	MaxTempF      float64        `json:"max_temp_F"`
+	MaxTempK      float64        `json:"max_temp_K"`
+	AvgTempC      float64        `json:"avg_temp_C"`
+	AvgTempF      float64        `json:"avg_temp_F"`
+	AvgTempK      float64        `json:"avg_temp_K"`
+	ChanceOfRain  int            `json:"chance_of_rain"`
+	TotalPrecipMm float64        `json:"total_precip_mm"`
+	AvgHumidity   float64        `json:"avg_humidity"`
+	Condition     string         `json:"condition,omitempty"`
+	Hours         []ForecastHour `json:"hours"`
+}
+
+type ForecastHour struct {
+	Time         time.Time `json:"time"`
+	TempC        float64   `json:"temp_C"`
+	TempF        float64   `json:"temp_F"`
+	TempK        float64   `json:"temp_K"`
+	ChanceOfRain int       `json:"chance_of_rain"`
+	PrecipMm     float64   `json:"precip_mm"`
+	Humidity     int       `json:"humidity"`
+	WindKph      float64   `json:"wind_kph"`
+	Condition    string    `json:"condition,omitempty"`
+}
+
+// DailyWeather is one day of a forecast as reported by the weather
+// provider, in Celsius.
+type DailyWeather struct {
+	Date          string
+	MinTempC      float64
+	MaxTempC      float64
+	AvgTempC      float64
+	ChanceOfRain  int
+	TotalPrecipMm float64
+	AvgHumidity   float64
+	Condition     string
+	Hours         []HourlyWeather
+}
+
+type HourlyWeather struct {
+	Time         time.Time
+	TempC        float64
+	ChanceOfRain int
+	PrecipMm     float64
+	Humidity     int
+	WindKph      float64
+	Condition    string
+}
+
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// BatchResult carries either the weather of a CEP or the problem that
+// prevented it from being resolved.
+type BatchResult struct {
+	CEP string `json:"cep"`
+	*WeatherResponse
+	Error *Problem `json:"error,omitempty"`
+}
+
+// ReverseResponse describes the municipality at the requested coordinates
+// and its current weather. It has no CEP: none of the CEP providers can look
+// one up from coordinates.
+type ReverseResponse struct {
+	City string  `json:"city"`
+	UF   string  `json:"uf,omitempty"`
+	IBGE string  `json:"ibge,omitempty"`
+	Lat  float64 `json:"lat"`
+	Lon  float64 `json:"lon"`
+	*WeatherResponse
+}
+
+type AddressSearchResponse struct {
+	Results []AddressSearchResult `json:"results"`
+}
+
+// AddressSearchResult is a candidate address of a search. Weather is only
+// filled when requested; WeatherError reports why it could not be.
+type AddressSearchResult struct {
+	ViaCEPAddress
+	Weather      *WeatherResponse `json:"weather,omitempty"`
+	WeatherError *Problem         `json:"weather_error,omitempty"`
 }
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+)
+
+// AddressHandler exposes the address of a CEP and its coordinates, resolved
+// through the same cached chain as the weather endpoints.
+type AddressHandler struct {
+	locator
+}
+
+func NewAddressHandler(viaCEP viacep.Client, geoClient openweathermap.Client) http.Handler {
+	return &AddressHandler{locator: locator{viaCEP: viaCEP, geoClient: geoClient}}
+}
+
+func (h *AddressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	cep, ok := parseCEPParam(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r, 5*time.Second)
+	defer cancel()
+
+	addr, coords, err := h.locateCEP(ctx, cep)
+	if err != nil {
+		writeUpstreamError(w, r, cep.String(), err)
+		return
+	}
+
+	// Providers differ in how they format the CEP and the UF.
+	resp := *addr
+	resp.Cep = cep.Format()
+	resp.Uf = strings.ToUpper(strings.TrimSpace(resp.Uf))
+	resp.Coordinates = coords
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
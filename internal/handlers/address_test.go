@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+func TestAddressHandler_Success(t *testing.T) {
+	h := NewAddressHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{
+			Cep: "01153000", Logradouro: "Rua Vitorino Carmilo", Bairro: "Barra Funda",
+			Localidade: "São Paulo", Uf: "sp", Ibge: "3550308", Ddd: "11",
+		}},
+		&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -23.53, Lon: -46.64}},
+	)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/address?cep=01153-000", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var addr domain.ViaCEPAddress
+	if err := json.Unmarshal(rec.Body.Bytes(), &addr); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if addr.Cep != "01153-000" || addr.Uf != "SP" || addr.Bairro != "Barra Funda" || addr.Ddd != "11" {
+		t.Fatalf("unexpected address: %+v", addr)
+	}
+	if addr.Coordinates == nil || addr.Coordinates.Lat != -23.53 {
+		t.Fatalf("expected resolved coordinates, got %+v", addr.Coordinates)
+	}
+}
+
+func TestAddressHandler_NotFound(t *testing.T) {
+	h := NewAddressHandler(&stubViaCEP{err: clients.NotFound("viacep", nil)}, &stubGeoClient{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/address?cep=99999999", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestAddressHandler_InvalidCEP(t *testing.T) {
+	h := NewAddressHandler(&stubViaCEP{}, &stubGeoClient{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/address?cep=abc", nil))
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/weatherapi"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/utils"
+)
+
+const maxBatchBodyBytes = 1 << 20
+
+type BatchConfig struct {
+	// MaxSize is the maximum number of CEPs accepted in one request.
+	MaxSize int
+	// Concurrency is the number of CEPs resolved at the same time.
+	Concurrency int
+}
+
+type BatchHandler struct {
+	weather *WeatherHandler
+	cfg     BatchConfig
+}
+
+func NewBatchHandler(viaCEP viacep.Client, geoClient openweathermap.Client, weather weatherapi.Client, cfg BatchConfig) http.Handler {
+	if cfg.Concurrency < 1 {
+		cfg.Concurrency = 1
+	}
+	return &BatchHandler{
+		weather: NewWeatherHandler(viaCEP, geoClient, weather).(*WeatherHandler),
+		cfg:     cfg,
+	}
+}
+
+func (h *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	opts, detail := parseResponseOptions(r)
+	if detail != "" {
+		writeProblem(w, r, problemInvalidParameter, detail, "")
+		return
+	}
+
+	if isNDJSON(r) {
+		h.stream(w, r, opts)
+		return
+	}
+
+	var ceps []string
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodyBytes)).Decode(&ceps); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeProblem(w, r, problemBatchTooLarge, "request body is too large", "")
+			return
+		}
+		writeProblem(w, r, problemInvalidBody, "body must be a JSON array of CEPs", "")
+		return
+	}
+	ceps = uniqueCEPs(ceps)
+	if len(ceps) > h.cfg.MaxSize {
+		writeProblem(w, r, problemBatchTooLarge,
+			"a batch accepts at most "+strconv.Itoa(h.cfg.MaxSize)+" distinct CEPs", "")
+		return
+	}
+
+	resp := domain.BatchResponse{Results: make([]domain.BatchResult, len(ceps))}
+	sem := make(chan struct{}, h.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i, cep := range ceps {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			resp.Results[i] = h.resolve(r, cep, opts)
+		}()
+	}
+	wg.Wait()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// resolve looks up a single CEP of the batch. Failures are reported in the
+// result instead of failing the whole batch.
+func (h *BatchHandler) resolve(r *http.Request, raw string, opts responseOptions) domain.BatchResult {
+	parsed, err := utils.ParseCEP(raw)
+	if err != nil {
+		p := newProblem(r, problemInvalidZipcode, err.Error(), raw)
+		return domain.BatchResult{CEP: raw, Error: &p}
+	}
+	cep := parsed.String()
+
+	ctx, cancel := contextWithTimeout(r, 5*time.Second)
+	defer cancel()
+
+	weather, err := h.weather.lookup(ctx, parsed, opts)
+	if err != nil {
+		p := upstreamErrorProblem(r, cep, err)
+		return domain.BatchResult{CEP: cep, Error: &p}
+	}
+	return domain.BatchResult{CEP: cep, WeatherResponse: weather}
+}
+
+// uniqueCEPs normalizes the CEPs and drops repeated ones, keeping the order
+// of their first occurrence. Invalid CEPs are kept as sent so the error can
+// be reported.
+func uniqueCEPs(ceps []string) []string {
+	seen := make(map[string]bool, len(ceps))
+	out := make([]string, 0, len(ceps))
+	for _, cep := range ceps {
+		if parsed, err := utils.ParseCEP(cep); err == nil {
+			cep = parsed.String()
+		} else {
+			cep = strings.TrimSpace(cep)
+		}
+		if seen[cep] {
+			continue
+		}
+		seen[cep] = true
+		out = append(out, cep)
+	}
+	return out
+}
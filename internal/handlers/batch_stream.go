@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+func isNDJSON(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return mediaType == ndjsonContentType
+}
+
+// stream resolves a batch sent as NDJSON, one JSON string per line, writing
+// each result as soon as it is ready. Neither the input nor the results are
+// held in memory: the body is only read while a worker is free, and workers
+// wait while the client is not consuming the response. Results are written
+// in completion order and CEPs are not de-duplicated.
+func (h *BatchHandler) stream(w http.ResponseWriter, r *http.Request, opts responseOptions) {
+	rc := http.NewResponseController(w)
+	// HTTP/1.x servers stop reading the body once the response starts.
+	// HTTP/2 is always full duplex and answers ErrNotSupported.
+	_ = rc.EnableFullDuplex()
+
+	w.Header().Set("Content-Type", ndjsonContentType)
+	w.WriteHeader(http.StatusOK)
+	_ = rc.Flush()
+
+	jobs := make(chan string)
+	results := make(chan domain.BatchResult, h.cfg.Concurrency)
+
+	var wg sync.WaitGroup
+	for range h.cfg.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for cep := range jobs {
+				results <- h.resolve(r, cep, opts)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		dec := json.NewDecoder(r.Body)
+		for {
+			var cep string
+			err := dec.Decode(&cep)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				p := newProblem(r, problemInvalidBody, "each line must be a JSON string with a CEP", "")
+				results <- domain.BatchResult{Error: &p}
+				return
+			}
+			select {
+			case jobs <- strings.TrimSpace(cep):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	enc := json.NewEncoder(w)
+	failed := false
+	for res := range results {
+		// Keep draining after a write error so the workers can finish.
+		if failed {
+			continue
+		}
+		if err := enc.Encode(res); err != nil {
+			failed = true
+			continue
+		}
+		if err := rc.Flush(); err != nil {
+			failed = true
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+func postNDJSON(t *testing.T, h http.Handler, body io.Reader) (*http.Response, []domain.BatchResult) {
+	t.Helper()
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Post(srv.URL, ndjsonContentType, body)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var results []domain.BatchResult
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var res domain.BatchResult
+		if err := json.Unmarshal(scanner.Bytes(), &res); err != nil {
+			t.Fatalf("invalid line %q: %v", scanner.Text(), err)
+		}
+		results = append(results, res)
+	}
+	return resp, results
+}
+
+func TestBatchHandler_StreamsNDJSON(t *testing.T) {
+	viaCEP := &countingViaCEP{missing: map[string]bool{"99999999": true}}
+	h := NewBatchHandler(viaCEP, &stubGeoClient{}, &stubWeather{tempC: 25}, BatchConfig{MaxSize: 1, Concurrency: 4})
+
+	// Streaming is not bound by the batch size limit.
+	var body strings.Builder
+	for i := range 500 {
+		fmt.Fprintf(&body, "%q\n", fmt.Sprintf("%08d", 1000000+i))
+	}
+	body.WriteString(`"99999999"` + "\n")
+
+	resp, results := postNDJSON(t, h, strings.NewReader(body.String()))
+	if ct := resp.Header.Get("Content-Type"); ct != ndjsonContentType {
+		t.Fatalf("unexpected content type %q", ct)
+	}
+	if len(results) != 501 {
+		t.Fatalf("expected 501 results, got %d", len(results))
+	}
+
+	failures := 0
+	for _, res := range results {
+		if res.Error != nil {
+			failures++
+			if res.CEP != "99999999" || res.Error.Status != http.StatusNotFound {
+				t.Fatalf("unexpected failure: %+v", res)
+			}
+		}
+	}
+	if failures != 1 {
+		t.Fatalf("expected 1 failure, got %d", failures)
+	}
+}
+
+func TestBatchHandler_StreamStopsAtInvalidLine(t *testing.T) {
+	h := NewBatchHandler(&countingViaCEP{}, &stubGeoClient{}, &stubWeather{tempC: 25}, BatchConfig{MaxSize: 10, Concurrency: 1})
+
+	_, results := postNDJSON(t, h, strings.NewReader("\"01153000\"\n{not json}\n\"20040020\"\n"))
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	var invalid int
+	for _, res := range results {
+		if res.Error != nil && res.Error.Type == problemInvalidBody.Type {
+			invalid++
+		}
+	}
+	if invalid != 1 {
+		t.Fatalf("expected an invalid body problem, got %+v", results)
+	}
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+// countingViaCEP resolves every CEP to the same coordinates, except the
+// ones listed in missing.
+type countingViaCEP struct {
+	mu      sync.Mutex
+	calls   map[string]int
+	missing map[string]bool
+}
+
+func (c *countingViaCEP) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = make(map[string]int)
+	}
+	c.calls[cep]++
+	if c.missing[cep] {
+		return nil, clients.NotFound("viacep", nil)
+	}
+	return &domain.ViaCEPAddress{Cep: cep, Coordinates: &domain.Coordinates{Lat: -23.55, Lon: -46.63}}, nil
+}
+
+func (c *countingViaCEP) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	return nil, clients.Unsupported("counting")
+}
+
+func postBatch(h http.Handler, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/weather/batch", strings.NewReader(body)))
+	return rec
+}
+
+func TestBatchHandler_ResultsPerCEP(t *testing.T) {
+	viaCEP := &countingViaCEP{missing: map[string]bool{"99999999": true}}
+	h := NewBatchHandler(viaCEP, &stubGeoClient{}, &stubWeather{tempC: 25}, BatchConfig{MaxSize: 10, Concurrency: 2})
+
+	rec := postBatch(h, `["01153000", "99999999", "abc", "01153-000", "20040020"]`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp domain.BatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(resp.Results) != 4 {
+		t.Fatalf("expected duplicated CEPs to be merged into 4 results, got %d", len(resp.Results))
+	}
+	if viaCEP.calls["01153000"] != 1 {
+		t.Fatalf("expected a single lookup for the duplicated CEP, got %d", viaCEP.calls["01153000"])
+	}
+
+	ok, notFound, invalid, second := resp.Results[0], resp.Results[1], resp.Results[2], resp.Results[3]
+	if ok.CEP != "01153000" || ok.WeatherResponse == nil || ok.TempC != 25 || ok.Error != nil {
+		t.Fatalf("unexpected result: %+v", ok)
+	}
+	if notFound.Error == nil || notFound.Error.Status != http.StatusNotFound {
+		t.Fatalf("expected not found error, got %+v", notFound)
+	}
+	if invalid.Error == nil || invalid.Error.Status != http.StatusUnprocessableEntity {
+		t.Fatalf("expected invalid zipcode error, got %+v", invalid)
+	}
+	if second.CEP != "20040020" || second.WeatherResponse == nil {
+		t.Fatalf("unexpected result: %+v", second)
+	}
+}
+
+func TestBatchHandler_TooLarge(t *testing.T) {
+	h := NewBatchHandler(&countingViaCEP{}, &stubGeoClient{}, &stubWeather{}, BatchConfig{MaxSize: 2, Concurrency: 1})
+
+	rec := postBatch(h, `["01153000", "20040020", "30140071"]`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", rec.Code)
+	}
+}
+
+func TestBatchHandler_InvalidBody(t *testing.T) {
+	h := NewBatchHandler(&countingViaCEP{}, &stubGeoClient{}, &stubWeather{}, BatchConfig{MaxSize: 2, Concurrency: 1})
+
+	for _, body := range []string{`{"cep": "01153000"}`, `not json`, `[1, 2]`} {
+		if rec := postBatch(h, body); rec.Code != http.StatusBadRequest {
+			t.Fatalf("body %s: expected 400, got %d", body, rec.Code)
+		}
+	}
+}
+
+func TestBatchHandler_MethodNotAllowed(t *testing.T) {
+	h := NewBatchHandler(&countingViaCEP{}, &stubGeoClient{}, &stubWeather{}, BatchConfig{MaxSize: 2, Concurrency: 1})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/weather/batch", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
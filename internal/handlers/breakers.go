@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+type BreakerStatusHandler struct {
+	breakers []*clients.Breaker
+}
+
+func NewBreakerStatusHandler(breakers ...*clients.Breaker) http.Handler {
+	return &BreakerStatusHandler{breakers: breakers}
+}
+
+func (h *BreakerStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	statuses := make([]clients.BreakerStatus, 0, len(h.breakers))
+	for _, b := range h.breakers {
+		statuses = append(statuses, b.Status())
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(statuses)
+}
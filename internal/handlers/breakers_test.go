@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+)
+
+func TestBreakerStatusHandler(t *testing.T) {
+	config := clients.BreakerConfig{FailureRatio: 0.5, MinRequests: 1, Window: time.Minute, CoolDown: time.Minute}
+	viaCEP := clients.NewBreaker("viacep", config)
+	weather := clients.NewBreaker("weatherapi", config)
+	ticket, _ := weather.Allow()
+	weather.Record(ticket, false)
+
+	h := NewBreakerStatusHandler(viaCEP, weather)
+	req := httptest.NewRequest(http.MethodGet, "/admin/breakers", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var statuses []clients.BreakerStatus
+	if err := json.Unmarshal(rec.Body.Bytes(), &statuses); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(statuses) != 2 || statuses[0].State != clients.StateClosed || statuses[1].State != clients.StateOpen {
+		t.Fatalf("unexpected statuses: %+v", statuses)
+	}
+}
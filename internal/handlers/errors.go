@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+const problemContentType = "application/problem+json"
+
+// problemKind identifies a class of error. The title of the invalid and not
+// found kinds are the messages required by the original specification.
+type problemKind struct {
+	Type   string
+	Title  string
+	Status int
+}
+
+var (
+	problemInvalidZipcode    = problemKind{"/problems/invalid-zipcode", "invalid zipcode", http.StatusUnprocessableEntity}
+	problemInvalidParameter  = problemKind{"/problems/invalid-parameter", "invalid parameter", http.StatusBadRequest}
+	problemInvalidBody       = problemKind{"/problems/invalid-body", "invalid request body", http.StatusBadRequest}
+	problemUnauthorized      = problemKind{"/problems/unauthorized", "unauthorized", http.StatusUnauthorized}
+	problemBatchTooLarge     = problemKind{"/problems/batch-too-large", "batch too large", http.StatusRequestEntityTooLarge}
+	problemZipcodeNotFound   = problemKind{"/problems/zipcode-not-found", "can not find zipcode", http.StatusNotFound}
+	problemLocationNotFound  = problemKind{"/problems/location-not-found", "can not find location", http.StatusNotFound}
+	problemRateLimited       = problemKind{"/problems/upstream-rate-limited", "upstream rate limit exceeded", http.StatusTooManyRequests}
+	problemUpstreamTimeout   = problemKind{"/problems/upstream-timeout", "upstream timeout", http.StatusGatewayTimeout}
+	problemUpstreamDown      = problemKind{"/problems/upstream-unavailable", "upstream unavailable", http.StatusServiceUnavailable}
+	problemBadUpstreamAnswer = problemKind{"/problems/bad-upstream-response", "bad upstream response", http.StatusBadGateway}
+	problemNotSupported      = problemKind{"/problems/not-supported", "not supported by the configured providers", http.StatusNotImplemented}
+)
+
+// upstreamProblem maps an error returned by the clients to the problem sent
+// to the caller, so that a bad CEP (404) can be told apart from a provider
+// failure (429, 502, 503 or 504).
+func upstreamProblem(err error) problemKind {
+	switch {
+	case errors.Is(err, clients.ErrNotFound):
+		return problemZipcodeNotFound
+	case errors.Is(err, clients.ErrRateLimited):
+		return problemRateLimited
+	case errors.Is(err, clients.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return problemUpstreamTimeout
+	case errors.Is(err, clients.ErrUnavailable):
+		return problemUpstreamDown
+	case errors.Is(err, clients.ErrUnsupported):
+		return problemNotSupported
+	default:
+		return problemBadUpstreamAnswer
+	}
+}
+
+func writeUpstreamError(w http.ResponseWriter, r *http.Request, cep string, err error) {
+	writeProblemDocument(w, upstreamErrorProblem(r, cep, err))
+}
+
+// upstreamErrorProblem builds the problem describing err, logging it unless
+// it is a plain not found.
+func upstreamErrorProblem(r *http.Request, cep string, err error) domain.Problem {
+	kind := upstreamProblem(err)
+	if kind.Status != http.StatusNotFound {
+		log.Printf("upstream error (request %s): %v", requestIDFrom(r), err)
+	}
+
+	var detail string
+	var clientErr *clients.Error
+	if errors.As(err, &clientErr) {
+		detail = "provider " + clientErr.Provider + ": " + clientErr.Kind.Error()
+	}
+	return newProblem(r, kind, detail, cep)
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, kind problemKind, detail, cep string) {
+	writeProblemDocument(w, newProblem(r, kind, detail, cep))
+}
+
+func newProblem(r *http.Request, kind problemKind, detail, cep string) domain.Problem {
+	return domain.Problem{
+		Type:      kind.Type,
+		Title:     kind.Title,
+		Status:    kind.Status,
+		Detail:    detail,
+		CEP:       cep,
+		RequestID: requestIDFrom(r),
+	}
+}
+
+func writeProblemDocument(w http.ResponseWriter, p domain.Problem) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
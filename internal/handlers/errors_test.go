@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+func TestWeatherHandler_InvalidCEPProblem(t *testing.T) {
+	h := RequestID(NewWeatherHandler(&stubViaCEP{}, &stubGeoClient{}, &stubWeather{}))
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=abc", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("expected problem+json content type, got %q", ct)
+	}
+
+	var p domain.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if p.Title != "invalid zipcode" || p.Status != http.StatusUnprocessableEntity || p.Type == "" {
+		t.Fatalf("unexpected problem: %+v", p)
+	}
+	if p.CEP != "abc" || p.RequestID != "req-123" {
+		t.Fatalf("expected CEP and request ID in problem, got %+v", p)
+	}
+}
+
+func TestWeatherHandler_NotFoundProblem(t *testing.T) {
+	h := RequestID(NewWeatherHandler(&stubViaCEP{err: clients.NotFound("viacep", nil)}, &stubGeoClient{}, &stubWeather{}))
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var p domain.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if p.Title != "can not find zipcode" || p.Status != http.StatusNotFound || p.CEP != "01153000" {
+		t.Fatalf("unexpected problem: %+v", p)
+	}
+	if p.RequestID == "" || p.RequestID != rec.Header().Get("X-Request-ID") {
+		t.Fatalf("expected generated request ID to match header, got %q and %q", p.RequestID, rec.Header().Get("X-Request-ID"))
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/weatherapi"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/utils"
+)
+
+const defaultForecastDays = 3
+
+type ForecastHandler struct {
+	locator
+	forecast weatherapi.ForecastClient
+}
+
+// NewForecastHandler builds the forecast endpoint. forecast may be nil when
+// WeatherAPI is not configured, in which case the endpoint answers 503.
+func NewForecastHandler(viaCEP viacep.Client, geoClient openweathermap.Client, forecast weatherapi.ForecastClient) http.Handler {
+	return &ForecastHandler{locator: locator{viaCEP: viaCEP, geoClient: geoClient}, forecast: forecast}
+}
+
+func (h *ForecastHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	parsed, ok := parseCEPParam(w, r)
+	if !ok {
+		return
+	}
+	cep := parsed.String()
+	days := defaultForecastDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > weatherapi.MaxForecastDays {
+			writeProblem(w, r, problemInvalidParameter,
+				"days must be between 1 and "+strconv.Itoa(weatherapi.MaxForecastDays), cep)
+			return
+		}
+		days = n
+	}
+	if h.forecast == nil {
+		writeProblem(w, r, problemUpstreamDown, "forecast provider not configured", cep)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r, 5*time.Second)
+	defer cancel()
+
+	_, coords, err := h.locateCEP(ctx, parsed)
+	if err != nil {
+		writeUpstreamError(w, r, cep, err)
+		return
+	}
+
+	daily, err := h.forecast.ForecastByCoords(ctx, coords.Lat, coords.Lon, days)
+	if err != nil {
+		writeUpstreamError(w, r, cep, err)
+		return
+	}
+
+	resp := domain.ForecastResponse{Days: make([]domain.ForecastDay, 0, len(daily))}
+	for _, d := range daily {
+		resp.Days = append(resp.Days, forecastDay(d))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func forecastDay(d domain.DailyWeather) domain.ForecastDay {
+	day := domain.ForecastDay{
+		Date:          d.Date,
+		MinTempC:      d.MinTempC,
+		MinTempF:      utils.CelsiusToFahrenheit(d.MinTempC),
+		MinTempK:      utils.CelsiusToKelvin(d.MinTempC),
+		MaxTempC:      d.MaxTempC,
+		MaxTempF:      utils.CelsiusToFahrenheit(d.MaxTempC),
+		MaxTempK:      utils.CelsiusToKelvin(d.MaxTempC),
+		AvgTempC:      d.AvgTempC,
+		AvgTempF:      utils.CelsiusToFahrenheit(d.AvgTempC),
+		AvgTempK:      utils.CelsiusToKelvin(d.AvgTempC),
+		ChanceOfRain:  d.ChanceOfRain,
+		TotalPrecipMm: d.TotalPrecipMm,
+		AvgHumidity:   d.AvgHumidity,
+		Condition:     d.Condition,
+		Hours:         make([]domain.ForecastHour, 0, len(d.Hours)),
+	}
+	for _, h := range d.Hours {
+		day.Hours = append(day.Hours, domain.ForecastHour{
+			Time:         h.Time,
+			TempC:        h.TempC,
+			TempF:        utils.CelsiusToFahrenheit(h.TempC),
+			TempK:        utils.CelsiusToKelvin(h.TempC),
+			ChanceOfRain: h.ChanceOfRain,
+			PrecipMm:     h.PrecipMm,
+			Humidity:     h.Humidity,
+			WindKph:      h.WindKph,
+			Condition:    h.Condition,
+		})
+	}
+	return day
+}
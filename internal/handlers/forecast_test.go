@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type stubForecast struct {
+	days  []domain.DailyWeather
+	err   error
+	asked int
+}
+
+func (s *stubForecast) ForecastByCoords(ctx context.Context, lat, lon float64, days int) ([]domain.DailyWeather, error) {
+	s.asked = days
+	return s.days, s.err
+}
+
+func TestForecastHandler_Success(t *testing.T) {
+	forecast := &stubForecast{days: []domain.DailyWeather{
+		{Date: "2025-01-15", MinTempC: 20, MaxTempC: 30, AvgTempC: 25, ChanceOfRain: 80,
+			Hours: []domain.HourlyWeather{{TempC: 22, ChanceOfRain: 10}}},
+		{Date: "2025-01-16", MinTempC: 19, MaxTempC: 28, AvgTempC: 23},
+	}}
+	h := NewForecastHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+		&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -23.55, Lon: -46.63}},
+		forecast,
+	)
+	req := httptest.NewRequest(http.MethodGet, "/api/forecast?cep=01153000&days=2", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if forecast.asked != 2 {
+		t.Fatalf("expected 2 days to be requested, got %d", forecast.asked)
+	}
+
+	var resp domain.ForecastResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(resp.Days) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(resp.Days))
+	}
+	day := resp.Days[0]
+	if day.MaxTempF != 86 || day.MinTempK != 293 || day.ChanceOfRain != 80 {
+		t.Fatalf("unexpected day: %+v", day)
+	}
+	if len(day.Hours) != 1 || day.Hours[0].TempF != 71.6 {
+		t.Fatalf("unexpected hours: %+v", day.Hours)
+	}
+}
+
+func TestForecastHandler_DefaultDays(t *testing.T) {
+	forecast := &stubForecast{}
+	h := NewForecastHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Coordinates: &domain.Coordinates{Lat: -23.55, Lon: -46.63}}},
+		&stubGeoClient{},
+		forecast,
+	)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/forecast?cep=01153000", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if forecast.asked != defaultForecastDays {
+		t.Fatalf("expected %d days, got %d", defaultForecastDays, forecast.asked)
+	}
+}
+
+func TestForecastHandler_InvalidDays(t *testing.T) {
+	h := NewForecastHandler(&stubViaCEP{}, &stubGeoClient{}, &stubForecast{})
+	for _, days := range []string{"0", "15", "abc"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/forecast?cep=01153000&days="+days, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("days=%s: expected 400, got %d", days, rec.Code)
+		}
+	}
+}
+
+func TestForecastHandler_NotConfigured(t *testing.T) {
+	h := NewForecastHandler(&stubViaCEP{}, &stubGeoClient{}, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/forecast?cep=01153000", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+}
+
+func TestForecastHandler_CEPNotFound(t *testing.T) {
+	h := NewForecastHandler(&stubViaCEP{err: clients.NotFound("viacep", nil)}, &stubGeoClient{}, &stubForecast{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/forecast?cep=01153000", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
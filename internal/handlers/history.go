@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/weatherapi"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+type HistoryHandler struct {
+	locator
+	history weatherapi.HistoryClient
+	now     func() time.Time
+}
+
+// NewHistoryHandler builds the history endpoint. history may be nil when
+// WeatherAPI is not configured, in which case the endpoint answers 503.
+func NewHistoryHandler(viaCEP viacep.Client, geoClient openweathermap.Client, history weatherapi.HistoryClient) http.Handler {
+	return &HistoryHandler{
+		locator: locator{viaCEP: viaCEP, geoClient: geoClient},
+		history: history,
+		now:     time.Now,
+	}
+}
+
+func (h *HistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	parsed, ok := parseCEPParam(w, r)
+	if !ok {
+		return
+	}
+	cep := parsed.String()
+	from, to, detail := h.dateRange(r)
+	if detail != "" {
+		writeProblem(w, r, problemInvalidParameter, detail, cep)
+		return
+	}
+	if h.history == nil {
+		writeProblem(w, r, problemUpstreamDown, "history provider not configured", cep)
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(r, 5*time.Second)
+	defer cancel()
+
+	_, coords, err := h.locateCEP(ctx, parsed)
+	if err != nil {
+		writeUpstreamError(w, r, cep, err)
+		return
+	}
+
+	daily, err := h.history.HistoryByCoords(ctx, coords.Lat, coords.Lon, from, to)
+	if err != nil {
+		writeUpstreamError(w, r, cep, err)
+		return
+	}
+
+	resp := domain.HistoryResponse{Days: make([]domain.ForecastDay, 0, len(daily))}
+	for _, d := range daily {
+		resp.Days = append(resp.Days, forecastDay(d))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// dateRange reads either ?date= or ?from=&to= and returns the problem
+// detail when the range is invalid.
+func (h *HistoryHandler) dateRange(r *http.Request) (from, to time.Time, detail string) {
+	q := r.URL.Query()
+	date, fromParam, toParam := q.Get("date"), q.Get("from"), q.Get("to")
+
+	switch {
+	case date != "" && (fromParam != "" || toParam != ""):
+		return from, to, "use either date or from/to"
+	case date != "":
+		fromParam, toParam = date, date
+	case fromParam == "" || toParam == "":
+		return from, to, "date or from and to are required"
+	}
+
+	from, err := time.Parse(dateLayout, fromParam)
+	if err != nil {
+		return from, to, "dates must use the YYYY-MM-DD format"
+	}
+	to, err = time.Parse(dateLayout, toParam)
+	if err != nil {
+		return from, to, "dates must use the YYYY-MM-DD format"
+	}
+
+	today, _ := time.Parse(dateLayout, h.now().Format(dateLayout))
+	switch {
+	case to.Before(from):
+		return from, to, "from must not be after to"
+	case to.After(today):
+		return from, to, "dates must not be in the future"
+	case to.Sub(from) >= weatherapi.MaxHistoryDays*24*time.Hour:
+		return from, to, "date range must not exceed " + strconv.Itoa(weatherapi.MaxHistoryDays) + " days"
+	}
+	return from, to, ""
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+type stubHistory struct {
+	days     []domain.DailyWeather
+	err      error
+	from, to time.Time
+}
+
+func (s *stubHistory) HistoryByCoords(ctx context.Context, lat, lon float64, from, to time.Time) ([]domain.DailyWeather, error) {
+	s.from, s.to = from, to
+	return s.days, s.err
+}
+
+func newTestHistoryHandler(history *stubHistory) *HistoryHandler {
+	h := NewHistoryHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Coordinates: &domain.Coordinates{Lat: -23.55, Lon: -46.63}}},
+		&stubGeoClient{},
+		history,
+	).(*HistoryHandler)
+	h.now = func() time.Time { return time.Date(2025, 1, 20, 15, 0, 0, 0, time.UTC) }
+	return h
+}
+
+func TestHistoryHandler_SingleDate(t *testing.T) {
+	history := &stubHistory{days: []domain.DailyWeather{{Date: "2025-01-10", MaxTempC: 30, ChanceOfRain: 100}}}
+	h := newTestHistoryHandler(history)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/history?cep=01153000&date=2025-01-10", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !history.from.Equal(history.to) || history.from.Format(dateLayout) != "2025-01-10" {
+		t.Fatalf("unexpected range %s - %s", history.from, history.to)
+	}
+
+	var resp domain.HistoryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(resp.Days) != 1 || resp.Days[0].MaxTempF != 86 {
+		t.Fatalf("unexpected days: %+v", resp.Days)
+	}
+}
+
+func TestHistoryHandler_Range(t *testing.T) {
+	history := &stubHistory{}
+	h := newTestHistoryHandler(history)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/history?cep=01153000&from=2025-01-01&to=2025-01-20", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if history.from.Format(dateLayout) != "2025-01-01" || history.to.Format(dateLayout) != "2025-01-20" {
+		t.Fatalf("unexpected range %s - %s", history.from, history.to)
+	}
+}
+
+func TestHistoryHandler_InvalidDates(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing", ""},
+		{"bad format", "&date=10/01/2025"},
+		{"date and range", "&date=2025-01-10&from=2025-01-01&to=2025-01-02"},
+		{"only from", "&from=2025-01-01"},
+		{"reversed", "&from=2025-01-10&to=2025-01-01"},
+		{"future", "&date=2025-01-21"},
+		{"too long", "&from=2024-12-01&to=2025-01-10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHistoryHandler(&stubHistory{})
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/history?cep=01153000"+tt.query, nil))
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d", rec.Code)
+			}
+		})
+	}
+}
+
+func TestHistoryHandler_NotConfigured(t *testing.T) {
+	h := NewHistoryHandler(&stubViaCEP{}, &stubGeoClient{}, nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/history?cep=01153000&date=2025-01-10", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+}
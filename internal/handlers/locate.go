@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/utils"
+)
+
+// errStateMismatch is returned when the CEP provider answers with a state
+// outside the Correios range of the CEP, which points to bad provider data.
+var errStateMismatch = errors.New("CEP provider returned a state outside the CEP range")
+
+// Geocoders reported in the location block of the weather response.
+const (
+	geocoderCEPProvider    = "cep_provider"
+	geocoderIBGE           = "ibge"
+	geocoderOpenWeatherMap = "openweathermap"
+	geocoderRequest        = "request"
+)
+
+// locator implements the CEP -> city -> coordinates pipeline shared by the
+// handlers that need the location of a CEP.
+type locator struct {
+	viaCEP    viacep.Client
+	geoClient openweathermap.Client
+}
+
+func (l *locator) locateCEP(ctx context.Context, cep utils.CEP) (*domain.ViaCEPAddress, *domain.Coordinates, error) {
+	addr, coords, _, err := l.resolveCEP(ctx, cep)
+	return addr, coords, err
+}
+
+// resolveCEP is locateCEP also reporting the geocoder that provided the
+// coordinates.
+func (l *locator) resolveCEP(ctx context.Context, cep utils.CEP) (*domain.ViaCEPAddress, *domain.Coordinates, string, error) {
+	addr, err := l.viaCEP.ConsultCEP(ctx, cep.String())
+	if err != nil {
+		return nil, nil, "", err
+	}
+	// Only known UFs are checked: a provider sending something else is
+	// handled by the geocoding step.
+	if uf := strings.ToUpper(strings.TrimSpace(addr.Uf)); domain.StateName(uf) != "" && uf != cep.State() {
+		return nil, nil, "", fmt.Errorf("%w: %s belongs to %s, got %s", errStateMismatch, cep.Format(), cep.State(), uf)
+	}
+
+	coords, geocoder, err := l.coordinates(ctx, addr)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return addr, coords, geocoder, nil
+}
+
+// coordinates uses the coordinates returned by the CEP provider when
+// available, then the IBGE code of the municipality, and finally the
+// city name through the geocoding API. It also reports which of them
+// answered.
+func (l *locator) coordinates(ctx context.Context, addr *domain.ViaCEPAddress) (*domain.Coordinates, string, error) {
+	if addr.Coordinates != nil {
+		return addr.Coordinates, geocoderCEPProvider, nil
+	}
+
+	if g, ok := l.geoClient.(openweathermap.IBGEGeocoder); ok && addr.Ibge != "" {
+		if geoLocation, err := g.GetCoordinatesByIBGE(ctx, addr.Ibge); err == nil {
+			return &domain.Coordinates{Lat: geoLocation.Lat, Lon: geoLocation.Lon}, geocoderIBGE, nil
+		}
+	}
+
+	geoLocation, err := l.geoClient.GetCoordinates(ctx, addr.Localidade, addr.Uf, "BR")
+	if err != nil {
+		return nil, "", err
+	}
+	return &domain.Coordinates{Lat: geoLocation.Lat, Lon: geoLocation.Lon}, geocoderOf(geoLocation), nil
+}
+
+// geocoderOf tells the embedded dataset apart from its OpenWeatherMap
+// fallback: only the dataset knows the IBGE code.
+func geocoderOf(loc *openweathermap.GeoLocation) string {
+	if loc.IBGE != "" {
+		return geocoderIBGE
+	}
+	return geocoderOpenWeatherMap
+}
+
+var ibgeCodeRegex = regexp.MustCompile(`^\d{7}$`)
+
+// locationQuery is the place the caller asked about: a CEP, a city and its
+// UF, an IBGE municipality code or coordinates. Only one of them is set.
+type locationQuery struct {
+	cep    utils.CEP
+	city   string
+	uf     string
+	ibge   string
+	coords *domain.Coordinates
+}
+
+// parseLocationQuery reads ?cep=, ?city=&uf=, ?ibge= or ?lat=&lon= and
+// returns the problem to send when they are missing, mixed or invalid.
+func parseLocationQuery(r *http.Request) (locationQuery, *domain.Problem) {
+	q := r.URL.Query()
+	invalid := func(detail string) (locationQuery, *domain.Problem) {
+		p := newProblem(r, problemInvalidParameter, detail, "")
+		return locationQuery{}, &p
+	}
+
+	modes := 0
+	for _, present := range []bool{
+		q.Has("cep"),
+		q.Has("city") || q.Has("uf"),
+		q.Has("ibge"),
+		q.Has("lat") || q.Has("lon"),
+	} {
+		if present {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return invalid("use only one of cep, city and uf, ibge or lat and lon")
+	}
+
+	// Without any parameter the CEP is reported as missing, as the endpoint
+	// originally only accepted ?cep=.
+	switch {
+	case modes == 0 || q.Has("cep"):
+		raw := q.Get("cep")
+		cep, err := utils.ParseCEP(raw)
+		if err != nil {
+			p := newProblem(r, problemInvalidZipcode, err.Error(), raw)
+			return locationQuery{}, &p
+		}
+		return locationQuery{cep: cep}, nil
+	case q.Has("ibge"):
+		code := strings.TrimSpace(q.Get("ibge"))
+		if !ibgeCodeRegex.MatchString(code) {
+			return invalid("ibge must contain exactly 7 digits")
+		}
+		return locationQuery{ibge: code}, nil
+	case q.Has("lat") || q.Has("lon"):
+		coords := domain.ParseCoordinates(q.Get("lat"), q.Get("lon"))
+		if coords == nil {
+			return invalid("lat must be between -90 and 90 and lon between -180 and 180")
+		}
+		return locationQuery{coords: coords}, nil
+	default:
+		city := strings.TrimSpace(q.Get("city"))
+		uf := strings.ToUpper(strings.TrimSpace(q.Get("uf")))
+		if city == "" {
+			return invalid("city must not be empty")
+		}
+		if domain.StateName(uf) == "" {
+			return invalid("uf must be a valid Brazilian state code")
+		}
+		return locationQuery{city: city, uf: uf}, nil
+	}
+}
+
+// locate enters the CEP pipeline at the stage matching the query: coordinates
+// skip everything, an IBGE code or a city skip the CEP providers. The
+// returned location holds whatever is known about the place.
+func (l *locator) locate(ctx context.Context, q locationQuery) (*domain.WeatherLocation, error) {
+	switch {
+	case q.coords != nil:
+		return &domain.WeatherLocation{Lat: q.coords.Lat, Lon: q.coords.Lon, Geocoder: geocoderRequest}, nil
+	case q.ibge != "":
+		g, ok := l.geoClient.(openweathermap.IBGEGeocoder)
+		if !ok {
+			return nil, clients.NotFound("ibge", errors.New("IBGE lookup not available"))
+		}
+		geoLocation, err := g.GetCoordinatesByIBGE(ctx, q.ibge)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.WeatherLocation{
+			City:     geoLocation.Name,
+			UF:       domain.StateCode(geoLocation.State),
+			IBGE:     q.ibge,
+			Lat:      geoLocation.Lat,
+			Lon:      geoLocation.Lon,
+			Geocoder: geocoderIBGE,
+		}, nil
+	case q.city != "":
+		geoLocation, err := l.geoClient.GetCoordinates(ctx, q.city, q.uf, "BR")
+		if err != nil {
+			return nil, err
+		}
+		return &domain.WeatherLocation{
+			City:     geoLocation.Name,
+			UF:       q.uf,
+			IBGE:     geoLocation.IBGE,
+			Lat:      geoLocation.Lat,
+			Lon:      geoLocation.Lon,
+			Geocoder: geocoderOf(geoLocation),
+		}, nil
+	default:
+		addr, coords, geocoder, err := l.resolveCEP(ctx, q.cep)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.WeatherLocation{
+			City:     addr.Localidade,
+			UF:       strings.ToUpper(strings.TrimSpace(addr.Uf)),
+			IBGE:     addr.Ibge,
+			Lat:      coords.Lat,
+			Lon:      coords.Lon,
+			Geocoder: geocoder,
+		}, nil
+	}
+}
+
+// problem describes err for the caller. Not found means "can not find
+// zipcode" only when the query was a CEP.
+func (q locationQuery) problem(r *http.Request, err error) domain.Problem {
+	p := upstreamErrorProblem(r, q.cep.String(), err)
+	if q.cep == "" && p.Type == problemZipcodeNotFound.Type {
+		p.Type, p.Title = problemLocationNotFound.Type, problemLocationNotFound.Title
+	}
+	return p
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestID_KeepsValidID(t *testing.T) {
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, id := range []string{"req-123", "A.b_C-9", strings.Repeat("a", 128)} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", id)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if got := rec.Header().Get("X-Request-ID"); got != id {
+			t.Fatalf("expected %q to be kept, got %q", id, got)
+		}
+	}
+}
+
+func TestRequestID_ReplacesInvalidID(t *testing.T) {
+	var seen string
+	h := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestIDFrom(r)
+	}))
+	for _, id := range []string{
+		strings.Repeat("a", 129),
+		"req 123",
+		"req\t123",
+		"<script>",
+		"req/123",
+		"réq",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("X-Request-ID", id)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		got := rec.Header().Get("X-Request-ID")
+		if got == id || !requestIDRegex.MatchString(got) {
+			t.Fatalf("expected %q to be replaced by a generated ID, got %q", id, got)
+		}
+		if seen != got {
+			t.Fatalf("expected handlers to see %q, got %q", got, seen)
+		}
+	}
+}
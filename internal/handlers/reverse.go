@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/weatherapi"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+// errOutsideBrazil is wrapped in the clients.ErrNotFound returned when the
+// coordinates resolve to a place in another country.
+var errOutsideBrazil = errors.New("coordinates are outside Brazil")
+
+type ReverseHandler struct {
+	weather *WeatherHandler
+}
+
+func NewReverseHandler(viaCEP viacep.Client, geoClient openweathermap.Client, weather weatherapi.Client) http.Handler {
+	return &ReverseHandler{weather: NewWeatherHandler(viaCEP, geoClient, weather).(*WeatherHandler)}
+}
+
+func (h *ReverseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	coords := domain.ParseCoordinates(r.URL.Query().Get("lat"), r.URL.Query().Get("lon"))
+	if coords == nil {
+		writeProblem(w, r, problemInvalidParameter, "lat must be between -90 and 90 and lon between -180 and 180", "")
+		return
+	}
+	detailed, ok := detailMode(r)
+	if !ok {
+		writeProblem(w, r, problemInvalidParameter, `detail must be "basic" or "full"`, "")
+		return
+	}
+	q := locationQuery{coords: coords}
+
+	ctx, cancel := contextWithTimeout(r, 5*time.Second)
+	defer cancel()
+
+	loc, err := h.weather.geoClient.ReverseGeocode(ctx, coords.Lat, coords.Lon)
+	if err == nil && loc.Country != "BR" {
+		err = clients.NotFound("geocoder", fmt.Errorf("%w: got country %q", errOutsideBrazil, loc.Country))
+	}
+	if err != nil {
+		writeProblemDocument(w, q.problem(r, err))
+		return
+	}
+
+	// The weather is taken at the requested point, not at the centroid of
+	// the municipality.
+	weather, err := h.weather.lookupLocation(ctx, q, responseOptions{detailed: detailed})
+	if err != nil {
+		writeProblemDocument(w, q.problem(r, err))
+		return
+	}
+
+	resp := domain.ReverseResponse{
+		City:            loc.Name,
+		UF:              domain.StateCode(loc.State),
+		IBGE:            loc.IBGE,
+		Lat:             coords.Lat,
+		Lon:             coords.Lon,
+		WeatherResponse: weather,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+func TestReverseHandler_Success(t *testing.T) {
+	geo := &stubGeoClient{location: &openweathermap.GeoLocation{Name: "Campinas", State: "São Paulo", Country: "BR", IBGE: "3509502"}}
+	h := NewReverseHandler(&stubViaCEP{}, geo, &stubWeather{tempC: 25})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/reverse?lat=-22.91&lon=-47.06", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp domain.ReverseResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if resp.City != "Campinas" || resp.UF != "SP" || resp.IBGE != "3509502" || resp.Lat != -22.91 {
+		t.Fatalf("unexpected location: %+v", resp)
+	}
+	if resp.WeatherResponse == nil || resp.TempC != 25 || resp.TempF != 77 {
+		t.Fatalf("unexpected weather: %+v", resp.WeatherResponse)
+	}
+}
+
+func TestReverseHandler_InvalidCoordinates(t *testing.T) {
+	h := NewReverseHandler(&stubViaCEP{}, &stubGeoClient{}, &stubWeather{})
+	for _, query := range []string{"", "lat=-22.91", "lat=abc&lon=1", "lat=10&lon=200"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/reverse?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%q: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestReverseHandler_NotFound(t *testing.T) {
+	h := NewReverseHandler(&stubViaCEP{}, &stubGeoClient{err: clients.NotFound("ibge", nil)}, &stubWeather{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/reverse?lat=0&lon=-30", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestReverseHandler_OutsideBrazil(t *testing.T) {
+	weather := &stubWeather{tempC: 20}
+	geo := &stubGeoClient{location: &openweathermap.GeoLocation{Name: "Montevideo", State: "Montevideo", Country: "UY"}}
+	h := NewReverseHandler(&stubViaCEP{}, geo, weather)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/reverse?lat=-34.9&lon=-56.16", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var problem domain.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if problem.Type != problemLocationNotFound.Type {
+		t.Fatalf("expected location not found, got %+v", problem)
+	}
+}
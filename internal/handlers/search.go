@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/weatherapi"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+// minSearchTermLength is the shortest city and street ViaCEP searches for.
+const minSearchTermLength = 3
+
+type AddressSearchHandler struct {
+	weather *WeatherHandler
+}
+
+func NewAddressSearchHandler(viaCEP viacep.Client, geoClient openweathermap.Client, weather weatherapi.Client) http.Handler {
+	return &AddressSearchHandler{weather: NewWeatherHandler(viaCEP, geoClient, weather).(*WeatherHandler)}
+}
+
+func (h *AddressSearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	q := r.URL.Query()
+	uf := strings.ToUpper(strings.TrimSpace(q.Get("uf")))
+	city := strings.TrimSpace(q.Get("city"))
+	street := strings.TrimSpace(q.Get("street"))
+	switch {
+	case domain.StateName(uf) == "":
+		writeProblem(w, r, problemInvalidParameter, "uf must be a valid Brazilian state code", "")
+		return
+	case utf8.RuneCountInString(city) < minSearchTermLength, utf8.RuneCountInString(street) < minSearchTermLength:
+		writeProblem(w, r, problemInvalidParameter,
+			"city and street must have at least "+strconv.Itoa(minSearchTermLength)+" characters", "")
+		return
+	}
+	withWeather := false
+	if v := q.Get("weather"); v != "" {
+		var err error
+		if withWeather, err = strconv.ParseBool(v); err != nil {
+			writeProblem(w, r, problemInvalidParameter, "weather must be true or false", "")
+			return
+		}
+	}
+
+	ctx, cancel := contextWithTimeout(r, 5*time.Second)
+	defer cancel()
+
+	addrs, err := h.weather.viaCEP.SearchAddress(ctx, uf, city, street)
+	if err != nil && !errors.Is(err, clients.ErrNotFound) {
+		writeUpstreamError(w, r, "", err)
+		return
+	}
+
+	resp := domain.AddressSearchResponse{Results: make([]domain.AddressSearchResult, 0, len(addrs))}
+	for _, addr := range addrs {
+		result := domain.AddressSearchResult{ViaCEPAddress: addr}
+		if withWeather {
+			// Candidates usually share the municipality, so after the first
+			// one the coordinates and the weather come from the caches.
+			weather, err := h.weatherAt(ctx, &addr)
+			if err != nil {
+				p := upstreamErrorProblem(r, addr.Cep, err)
+				result.WeatherError = &p
+			}
+			result.Weather = weather
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *AddressSearchHandler) weatherAt(ctx context.Context, addr *domain.ViaCEPAddress) (*domain.WeatherResponse, error) {
+	coords, _, err := h.weather.coordinates(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	return h.weather.lookupLocation(ctx, locationQuery{coords: coords}, responseOptions{})
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/viacep"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+)
+
+func TestAddressSearchHandler_WithWeather(t *testing.T) {
+	h := NewAddressSearchHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Cep: "91420-270", Localidade: "Porto Alegre", Uf: "RS"}},
+		&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -30.03, Lon: -51.23}},
+		&stubWeather{tempC: 18},
+	)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/cep/search?uf=rs&city=Porto%20Alegre&street=Domingos&weather=true", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp domain.AddressSearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(resp.Results) != 1 || resp.Results[0].Cep != "91420-270" {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+	if resp.Results[0].Weather == nil || resp.Results[0].Weather.TempC != 18 {
+		t.Fatalf("expected weather, got %+v", resp.Results[0])
+	}
+}
+
+func TestAddressSearchHandler_WithoutWeather(t *testing.T) {
+	h := NewAddressSearchHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Cep: "91420-270"}},
+		&stubGeoClient{},
+		&stubWeather{},
+	)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/cep/search?uf=RS&city=Porto%20Alegre&street=Domingos", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var resp domain.AddressSearchResponse
+	_ = json.Unmarshal(rec.Body.Bytes(), &resp)
+	if len(resp.Results) != 1 || resp.Results[0].Weather != nil || resp.Results[0].WeatherError != nil {
+		t.Fatalf("unexpected results: %+v", resp.Results)
+	}
+}
+
+func TestAddressSearchHandler_NoResults(t *testing.T) {
+	h := NewAddressSearchHandler(&stubViaCEP{err: clients.NotFound("viacep", nil)}, &stubGeoClient{}, &stubWeather{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/cep/search?uf=RS&city=Porto%20Alegre&street=Inexistente", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if body := rec.Body.String(); body != "{\"results\":[]}\n" {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
+
+func TestAddressSearchHandler_Unsupported(t *testing.T) {
+	h := NewAddressSearchHandler(&recordingViaCEP{}, &stubGeoClient{}, &stubWeather{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/cep/search?uf=RS&city=Porto%20Alegre&street=Domingos", nil))
+	if rec.Code != http.StatusNotImplemented {
+		t.Fatalf("expected 501, got %d", rec.Code)
+	}
+}
+
+func TestAddressSearchHandler_SearchProviderDown(t *testing.T) {
+	cepClient := viacep.NewFailoverClient(0,
+		viacep.Provider{Name: "viacep", Client: &stubViaCEP{err: clients.StatusError("viacep", 503)}},
+		viacep.Provider{Name: "recording", Client: &recordingViaCEP{}},
+	)
+	h := NewAddressSearchHandler(cepClient, &stubGeoClient{}, &stubWeather{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/cep/search?uf=RS&city=Porto%20Alegre&street=Domingos", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAddressSearchHandler_InvalidParameters(t *testing.T) {
+	h := NewAddressSearchHandler(&stubViaCEP{}, &stubGeoClient{}, &stubWeather{})
+	for _, query := range []string{
+		"city=Porto%20Alegre&street=Domingos",
+		"uf=XX&city=Porto%20Alegre&street=Domingos",
+		"uf=RS&city=PA&street=Domingos",
+		"uf=RS&city=Porto%20Alegre&street=Do",
+		"uf=RS&city=Porto%20Alegre&street=Domingos&weather=maybe",
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/cep/search?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
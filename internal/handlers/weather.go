@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
@@ -15,13 +15,12 @@ import (
 )
 
 type WeatherHandler struct {
-	viaCEP     viacep.Client
-	geoClient  openweathermap.Client
+	locator
 	weatherAPI weatherapi.Client
 }
 
 func NewWeatherHandler(viaCEP viacep.Client, geoClient openweathermap.Client, weather weatherapi.Client) http.Handler {
-	return &WeatherHandler{viaCEP: viaCEP, geoClient: geoClient, weatherAPI: weather}
+	return &WeatherHandler{locator: locator{viaCEP: viaCEP, geoClient: geoClient}, weatherAPI: weather}
 }
 
 func (h *WeatherHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -29,43 +28,133 @@ func (h *WeatherHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
-	cep := strings.TrimSpace(r.URL.Query().Get("cep"))
-	if !utils.IsValidCEP(cep) {
-		http.Error(w, "invalid zipcode", http.StatusUnprocessableEntity)
+	q, problem := parseLocationQuery(r)
+	if problem != nil {
+		writeProblemDocument(w, *problem)
+		return
+	}
+	opts, detail := parseResponseOptions(r)
+	if detail != "" {
+		writeProblem(w, r, problemInvalidParameter, detail, q.cep.String())
 		return
 	}
 
 	ctx, cancel := contextWithTimeout(r, 5*time.Second)
 	defer cancel()
 
-	addr, status, err := h.viaCEP.ConsultCEP(ctx, cep)
-	if err != nil || status >= 400 || addr == nil || addr.Erro {
-		http.Error(w, "can not find zipcode", http.StatusNotFound)
+	resp, err := h.lookupLocation(ctx, q, opts)
+	if err != nil {
+		writeProblemDocument(w, q.problem(r, err))
 		return
 	}
 
-	// Get coordinates from OpenWeatherMap Geocoding API
-	geoLocation, statusGeo, err := h.geoClient.GetCoordinates(ctx, addr.Localidade, "BR")
-	if err != nil || statusGeo >= 400 || geoLocation == nil {
-		http.Error(w, "can not find zipcode", http.StatusNotFound)
-		return
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *WeatherHandler) lookup(ctx context.Context, cep utils.CEP, opts responseOptions) (*domain.WeatherResponse, error) {
+	return h.lookupLocation(ctx, locationQuery{cep: cep}, opts)
+}
+
+func (h *WeatherHandler) lookupLocation(ctx context.Context, q locationQuery, opts responseOptions) (*domain.WeatherResponse, error) {
+	location, err := h.locate(ctx, q)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get temperature using coordinates
-	tempC, statusW, err := h.weatherAPI.CurrentTempCByCoords(ctx, geoLocation.Lat, geoLocation.Lon)
-	if err != nil || statusW >= 400 {
-		http.Error(w, "can not find zipcode", http.StatusNotFound)
-		return
+	// Get current conditions using coordinates
+	current, err := h.weatherAPI.CurrentByCoords(ctx, location.Lat, location.Lon)
+	if err != nil {
+		return nil, err
 	}
 
-	resp := domain.WeatherResponse{
-		TempC: tempC,
-		TempF: utils.CelsiusToFahrenheit(tempC),
-		TempK: utils.CelsiusToKelvin(tempC),
+	resp := &domain.WeatherResponse{
+		TempC: current.TempC,
+		TempF: utils.CelsiusToFahrenheit(current.TempC),
+		TempK: utils.CelsiusToKelvin(current.TempC),
+	}
+	if opts.detailed {
+		resp.Details = weatherDetails(current)
+	}
+	if opts.location {
+		if !current.LastUpdated.IsZero() {
+			observedAt := current.LastUpdated
+			location.ObservedAt = &observedAt
+		}
+		resp.Location = location
+	}
+	return resp, nil
+}
+
+// parseCEPParam reads the cep query parameter, writing the problem when it
+// can not be parsed.
+func parseCEPParam(w http.ResponseWriter, r *http.Request) (utils.CEP, bool) {
+	raw := r.URL.Query().Get("cep")
+	cep, err := utils.ParseCEP(raw)
+	if err != nil {
+		writeProblem(w, r, problemInvalidZipcode, err.Error(), raw)
+		return "", false
+	}
+	return cep, true
+}
+
+// responseOptions are the opt-in parts of the weather payload.
+type responseOptions struct {
+	detailed bool
+	location bool
+}
+
+// parseResponseOptions reads ?detail= and ?location=, returning the problem
+// detail when one of them is invalid.
+func parseResponseOptions(r *http.Request) (responseOptions, string) {
+	var opts responseOptions
+	detailed, ok := detailMode(r)
+	if !ok {
+		return opts, `detail must be "basic" or "full"`
+	}
+	opts.detailed = detailed
+	if v := r.URL.Query().Get("location"); v != "" {
+		location, err := strconv.ParseBool(v)
+		if err != nil {
+			return opts, "location must be true or false"
+		}
+		opts.location = location
+	}
+	return opts, ""
+}
+
+// detailMode reports whether the caller asked for the detailed payload with
+// ?detail=full. The default payload keeps the original temp_C/temp_F/temp_K
+// contract.
+func detailMode(r *http.Request) (full bool, ok bool) {
+	switch r.URL.Query().Get("detail") {
+	case "", "basic":
+		return false, true
+	case "full":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+func weatherDetails(c *domain.CurrentWeather) *domain.WeatherDetails {
+	return &domain.WeatherDetails{
+		FeelsLikeC:    c.FeelsLikeC,
+		FeelsLikeF:    utils.CelsiusToFahrenheit(c.FeelsLikeC),
+		FeelsLikeK:    utils.CelsiusToKelvin(c.FeelsLikeC),
+		Humidity:      c.Humidity,
+		WindKph:       c.WindKph,
+		WindDegree:    c.WindDegree,
+		WindDir:       c.WindDir,
+		PressureMb:    c.PressureMb,
+		PrecipMm:      c.PrecipMm,
+		UV:            c.UV,
+		Cloud:         c.Cloud,
+		Condition:     c.Condition,
+		ConditionIcon: c.ConditionIcon,
+		LastUpdated:   c.LastUpdated,
 	}
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_ = json.NewEncoder(w).Encode(resp)
 }
 
 func contextWithTimeout(r *http.Request, d time.Duration) (context.Context, context.CancelFunc) {
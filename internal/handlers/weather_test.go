@@ -7,43 +7,57 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients"
 	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/clients/openweathermap"
 	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/domain"
+	"github.com/jonilsonds9/goexpert-desafio-labs-climate-by-zip-code/internal/utils"
 )
 
 type stubViaCEP struct {
-	addr   *domain.ViaCEPAddress
-	status int
-	err    error
+	addr *domain.ViaCEPAddress
+	err  error
 }
 
-func (s *stubViaCEP) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, int, error) {
-	return s.addr, s.status, s.err
+func (s *stubViaCEP) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	return s.addr, s.err
 }
 
-type stubWeather struct {
-	tempC  float64
-	status int
-	err    error
+func (s *stubViaCEP) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return []domain.ViaCEPAddress{*s.addr}, nil
 }
 
-func (s *stubWeather) CurrentTempC(ctx context.Context, location string) (float64, int, error) {
-	return s.tempC, s.status, s.err
+type stubWeather struct {
+	tempC   float64
+	current *domain.CurrentWeather
+	err     error
 }
 
-func (s *stubWeather) CurrentTempCByCoords(ctx context.Context, lat, lon float64) (float64, int, error) {
-	return s.tempC, s.status, s.err
+func (s *stubWeather) CurrentByCoords(ctx context.Context, lat, lon float64) (*domain.CurrentWeather, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.current != nil {
+		return s.current, nil
+	}
+	return &domain.CurrentWeather{TempC: s.tempC}, nil
 }
 
 type stubGeoClient struct {
 	location *openweathermap.GeoLocation
-	status   int
 	err      error
 }
 
-func (s *stubGeoClient) GetCoordinates(ctx context.Context, cityName, countryCode string) (*openweathermap.GeoLocation, int, error) {
-	return s.location, s.status, s.err
+func (s *stubGeoClient) GetCoordinates(ctx context.Context, cityName, stateCode, countryCode string) (*openweathermap.GeoLocation, error) {
+	return s.location, s.err
+}
+
+func (s *stubGeoClient) ReverseGeocode(ctx context.Context, lat, lon float64) (*openweathermap.GeoLocation, error) {
+	return s.location, s.err
 }
 
 func TestWeatherHandler_Success(t *testing.T) {
@@ -55,9 +69,9 @@ func TestWeatherHandler_Success(t *testing.T) {
 		State:   "São Paulo",
 	}
 	h := NewWeatherHandler(
-		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}, status: 200},
-		&stubGeoClient{location: geoLoc, status: 200},
-		&stubWeather{tempC: 25.0, status: 200},
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+		&stubGeoClient{location: geoLoc},
+		&stubWeather{tempC: 25.0},
 	)
 	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
 	rec := httptest.NewRecorder()
@@ -85,7 +99,7 @@ func TestWeatherHandler_InvalidCEP(t *testing.T) {
 }
 
 func TestWeatherHandler_NotFound(t *testing.T) {
-	h := NewWeatherHandler(&stubViaCEP{addr: &domain.ViaCEPAddress{Erro: true}, status: 200}, &stubGeoClient{}, &stubWeather{})
+	h := NewWeatherHandler(&stubViaCEP{err: clients.NotFound("viacep", nil)}, &stubGeoClient{}, &stubWeather{})
 	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
@@ -96,29 +110,29 @@ func TestWeatherHandler_NotFound(t *testing.T) {
 
 func TestWeatherHandler_ViaCEPError(t *testing.T) {
 	h := NewWeatherHandler(
-		&stubViaCEP{addr: nil, status: 500, err: errors.New("service unavailable")},
+		&stubViaCEP{addr: nil, err: clients.StatusError("viacep", 500)},
 		&stubGeoClient{},
-		&stubWeather{tempC: 25.0, status: 200},
+		&stubWeather{tempC: 25.0},
 	)
 	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
-	if rec.Code != http.StatusNotFound {
-		t.Fatalf("expected 404, got %d", rec.Code)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
 	}
 }
 
 func TestWeatherHandler_GeoClientError(t *testing.T) {
 	h := NewWeatherHandler(
-		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}, status: 200},
-		&stubGeoClient{location: nil, status: 500, err: errors.New("geocoding service unavailable")},
-		&stubWeather{tempC: 25.0, status: 200},
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+		&stubGeoClient{location: nil, err: clients.StatusError("openweathermap", 500)},
+		&stubWeather{tempC: 25.0},
 	)
 	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
-	if rec.Code != http.StatusNotFound {
-		t.Fatalf("expected 404, got %d", rec.Code)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
 	}
 }
 
@@ -131,15 +145,15 @@ func TestWeatherHandler_WeatherAPIError(t *testing.T) {
 		State:   "São Paulo",
 	}
 	h := NewWeatherHandler(
-		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}, status: 200},
-		&stubGeoClient{location: geoLoc, status: 200},
-		&stubWeather{tempC: 0, status: 500, err: errors.New("weather service unavailable")},
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+		&stubGeoClient{location: geoLoc},
+		&stubWeather{tempC: 0, err: clients.StatusError("weatherapi", 500)},
 	)
 	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
-	if rec.Code != http.StatusNotFound {
-		t.Fatalf("expected 404, got %d", rec.Code)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
 	}
 }
 
@@ -163,14 +177,51 @@ func TestWeatherHandler_EmptyCEP(t *testing.T) {
 	}
 }
 
-func TestWeatherHandler_CEPWithHyphen(t *testing.T) {
+func TestWeatherHandler_FormattedCEP(t *testing.T) {
+	for _, cep := range []string{"01153-000", "01.153-000", "%2001153000%20"} {
+		viaCEP := &recordingViaCEP{addr: &domain.ViaCEPAddress{Coordinates: &domain.Coordinates{Lat: -23.55, Lon: -46.63}}}
+		h := NewWeatherHandler(viaCEP, &stubGeoClient{}, &stubWeather{tempC: 25})
+		req := httptest.NewRequest(http.MethodGet, "/weather?cep="+cep, nil)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("cep %s: expected 200, got %d", cep, rec.Code)
+		}
+		if viaCEP.cep != "01153000" {
+			t.Fatalf("cep %s: expected the normalized CEP to be looked up, got %q", cep, viaCEP.cep)
+		}
+	}
+}
+
+func TestWeatherHandler_MalformedCEPReportsReason(t *testing.T) {
 	h := NewWeatherHandler(&stubViaCEP{}, &stubGeoClient{}, &stubWeather{})
-	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153-000", nil)
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=0115-3000", nil)
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
 	if rec.Code != http.StatusUnprocessableEntity {
 		t.Fatalf("expected 422, got %d", rec.Code)
 	}
+	var problem domain.Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if problem.Detail != utils.ErrCEPSeparator.Error() || problem.CEP != "0115-3000" {
+		t.Fatalf("unexpected problem: %+v", problem)
+	}
+}
+
+type recordingViaCEP struct {
+	addr *domain.ViaCEPAddress
+	cep  string
+}
+
+func (s *recordingViaCEP) ConsultCEP(ctx context.Context, cep string) (*domain.ViaCEPAddress, error) {
+	s.cep = cep
+	return s.addr, nil
+}
+
+func (s *recordingViaCEP) SearchAddress(ctx context.Context, uf, city, street string) ([]domain.ViaCEPAddress, error) {
+	return nil, clients.Unsupported("recording")
 }
 
 func TestWeatherHandler_TemperatureConversion(t *testing.T) {
@@ -182,9 +233,9 @@ func TestWeatherHandler_TemperatureConversion(t *testing.T) {
 		State:   "São Paulo",
 	}
 	h := NewWeatherHandler(
-		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}, status: 200},
-		&stubGeoClient{location: geoLoc, status: 200},
-		&stubWeather{tempC: 28.5, status: 200},
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+		&stubGeoClient{location: geoLoc},
+		&stubWeather{tempC: 28.5},
 	)
 	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
 	rec := httptest.NewRecorder()
@@ -219,9 +270,9 @@ func TestWeatherHandler_NegativeTemperature(t *testing.T) {
 		State:   "Moscow",
 	}
 	h := NewWeatherHandler(
-		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Moscow", Uf: "MC"}, status: 200},
-		&stubGeoClient{location: geoLoc, status: 200},
-		&stubWeather{tempC: -10.0, status: 200},
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Moscow", Uf: "MC"}},
+		&stubGeoClient{location: geoLoc},
+		&stubWeather{tempC: -10.0},
 	)
 	req := httptest.NewRequest(http.MethodGet, "/weather?cep=12345678", nil)
 	rec := httptest.NewRecorder()
@@ -246,3 +297,318 @@ func TestWeatherHandler_NegativeTemperature(t *testing.T) {
 		t.Errorf("expected tempK 263.0, got %v", resp.TempK)
 	}
 }
+
+func TestWeatherHandler_UsesProviderCoordinates(t *testing.T) {
+	h := NewWeatherHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{
+			Localidade:  "Sao Paulo",
+			Uf:          "SP",
+			Coordinates: &domain.Coordinates{Lat: -23.5505, Lon: -46.6333},
+		}},
+		&stubGeoClient{location: nil, err: errors.New("geocoding should not be called")},
+		&stubWeather{tempC: 22.0},
+	)
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+type stubIBGEGeoClient struct {
+	stubGeoClient
+	ibgeLocation *openweathermap.GeoLocation
+}
+
+func (s *stubIBGEGeoClient) GetCoordinatesByIBGE(ctx context.Context, ibgeCode string) (*openweathermap.GeoLocation, error) {
+	if s.ibgeLocation == nil {
+		return nil, clients.NotFound("ibge", nil)
+	}
+	return s.ibgeLocation, nil
+}
+
+func TestWeatherHandler_UsesIBGEGeocoder(t *testing.T) {
+	h := NewWeatherHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP", Ibge: "3550308"}},
+		&stubIBGEGeoClient{
+			stubGeoClient: stubGeoClient{location: nil, err: errors.New("geocoding should not be called")},
+			ibgeLocation:  &openweathermap.GeoLocation{Name: "São Paulo", Lat: -23.5329, Lon: -46.6395},
+		},
+		&stubWeather{tempC: 22.0},
+	)
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
+
+func TestWeatherHandler_UpstreamErrorMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{name: "Not found", err: clients.NotFound("weatherapi", nil), expected: http.StatusNotFound},
+		{name: "Rate limited", err: clients.StatusError("weatherapi", 429), expected: http.StatusTooManyRequests},
+		{name: "Timeout", err: clients.TransportError("weatherapi", context.DeadlineExceeded), expected: http.StatusGatewayTimeout},
+		{name: "Unavailable", err: clients.StatusError("weatherapi", 503), expected: http.StatusServiceUnavailable},
+		{name: "Bad credentials", err: clients.StatusError("weatherapi", 401), expected: http.StatusBadGateway},
+		{name: "Decode failure", err: clients.DecodeError("weatherapi", 200, errors.New("unexpected EOF")), expected: http.StatusBadGateway},
+		{name: "Unknown error", err: errors.New("boom"), expected: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewWeatherHandler(
+				&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+				&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -23.5505, Lon: -46.6333}},
+				&stubWeather{err: tt.err},
+			)
+			req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != tt.expected {
+				t.Fatalf("expected %d, got %d", tt.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestWeatherHandler_DefaultPayloadHasNoDetails(t *testing.T) {
+	h := NewWeatherHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+		&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -23.5505, Lon: -46.6333}},
+		&stubWeather{current: &domain.CurrentWeather{TempC: 28.5, Humidity: 60}},
+	)
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(body) != 3 {
+		t.Fatalf("expected only temp_C, temp_F and temp_K, got %v", body)
+	}
+}
+
+func TestWeatherHandler_DetailedPayload(t *testing.T) {
+	h := NewWeatherHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+		&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -23.5505, Lon: -46.6333}},
+		&stubWeather{current: &domain.CurrentWeather{
+			TempC:      28.5,
+			FeelsLikeC: 30,
+			Humidity:   60,
+			WindKph:    12.5,
+			WindDir:    "SE",
+			Condition:  "Partly cloudy",
+		}},
+	)
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000&detail=full", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	var resp domain.WeatherResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if resp.TempC != 28.5 || resp.Details == nil {
+		t.Fatalf("expected detailed payload, got %+v", resp)
+	}
+	if resp.Details.FeelsLikeF != 86 || resp.Details.Humidity != 60 || resp.Details.WindDir != "SE" || resp.Details.Condition != "Partly cloudy" {
+		t.Fatalf("unexpected details: %+v", resp.Details)
+	}
+}
+
+func TestWeatherHandler_InvalidDetailMode(t *testing.T) {
+	h := NewWeatherHandler(&stubViaCEP{}, &stubGeoClient{}, &stubWeather{})
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000&detail=everything", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestWeatherHandler_CEPOutOfRangeSkipsProviders(t *testing.T) {
+	viaCEP := &recordingViaCEP{}
+	h := NewWeatherHandler(viaCEP, &stubGeoClient{}, &stubWeather{})
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=00000000", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", rec.Code)
+	}
+	if viaCEP.cep != "" {
+		t.Fatalf("expected no provider call, got one for %q", viaCEP.cep)
+	}
+}
+
+func TestWeatherHandler_ProviderStateMismatch(t *testing.T) {
+	h := NewWeatherHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Rio de Janeiro", Uf: "RJ"}},
+		&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -22.9, Lon: -43.2}},
+		&stubWeather{tempC: 25},
+	)
+	req := httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", rec.Code)
+	}
+}
+
+func TestWeatherHandler_AlternativeLocations(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		geo   openweathermap.Client
+	}{
+		{"city and uf", "city=Sao%20Paulo&uf=sp", &stubGeoClient{location: &openweathermap.GeoLocation{Lat: -23.55, Lon: -46.63}}},
+		{"ibge", "ibge=3550308", &stubIBGEGeoClient{ibgeLocation: &openweathermap.GeoLocation{Lat: -23.55, Lon: -46.63}}},
+		{"coordinates", "lat=-23.55&lon=-46.63", &stubGeoClient{err: errors.New("geocoding must be skipped")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viaCEP := &recordingViaCEP{}
+			h := NewWeatherHandler(viaCEP, tt.geo, &stubWeather{tempC: 25})
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weather?"+tt.query, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if viaCEP.cep != "" {
+				t.Fatalf("expected the CEP providers to be skipped")
+			}
+		})
+	}
+}
+
+func TestWeatherHandler_InvalidLocationParameters(t *testing.T) {
+	for _, query := range []string{
+		"cep=01153000&lat=1&lon=1",
+		"city=Sao%20Paulo",
+		"city=&uf=SP",
+		"city=Sao%20Paulo&uf=XX",
+		"ibge=355030",
+		"lat=-95&lon=10",
+		"lat=-23.55",
+		"lon=-46.63",
+	} {
+		h := NewWeatherHandler(&stubViaCEP{}, &stubGeoClient{}, &stubWeather{})
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weather?"+query, nil))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestWeatherHandler_CityNotFound(t *testing.T) {
+	h := NewWeatherHandler(&stubViaCEP{}, &stubGeoClient{err: clients.NotFound("ibge", nil)}, &stubWeather{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weather?city=Nowhere&uf=SP", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	var problem domain.Problem
+	_ = json.Unmarshal(rec.Body.Bytes(), &problem)
+	if problem.Type != problemLocationNotFound.Type {
+		t.Fatalf("unexpected problem: %+v", problem)
+	}
+}
+
+func TestWeatherHandler_DefaultPayloadHasNoLocation(t *testing.T) {
+	h := NewWeatherHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}},
+		&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -23.55, Lon: -46.63}},
+		&stubWeather{tempC: 25},
+	)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weather?cep=01153000", nil))
+	if body := rec.Body.String(); body != "{\"temp_C\":25,\"temp_F\":77,\"temp_K\":298}\n" {
+		t.Fatalf("unexpected body %q", body)
+	}
+}
+
+func TestWeatherHandler_LocationBlock(t *testing.T) {
+	observed := time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC)
+	h := NewWeatherHandler(
+		&stubViaCEP{addr: &domain.ViaCEPAddress{Localidade: "São Paulo", Uf: "sp", Ibge: "3550308"}},
+		&stubIBGEGeoClient{ibgeLocation: &openweathermap.GeoLocation{Name: "São Paulo", Lat: -23.53, Lon: -46.64, IBGE: "3550308"}},
+		&stubWeather{current: &domain.CurrentWeather{TempC: 25, LastUpdated: observed}},
+	)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weather?cep=01153000&location=true", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp domain.WeatherResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	loc := resp.Location
+	if loc == nil {
+		t.Fatal("expected a location block")
+	}
+	if loc.City != "São Paulo" || loc.UF != "SP" || loc.IBGE != "3550308" || loc.Lat != -23.53 || loc.Geocoder != geocoderIBGE {
+		t.Fatalf("unexpected location: %+v", loc)
+	}
+	if loc.ObservedAt == nil || !loc.ObservedAt.Equal(observed) {
+		t.Fatalf("unexpected observation time: %v", loc.ObservedAt)
+	}
+}
+
+func TestWeatherHandler_LocationBlockGeocoders(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		addr  *domain.ViaCEPAddress
+		want  string
+	}{
+		{"cep provider coordinates", "cep=01153000", &domain.ViaCEPAddress{Uf: "SP", Coordinates: &domain.Coordinates{Lat: -23.5, Lon: -46.6}}, geocoderCEPProvider},
+		{"geocoding api", "cep=01153000", &domain.ViaCEPAddress{Localidade: "Sao Paulo", Uf: "SP"}, geocoderOpenWeatherMap},
+		{"request coordinates", "lat=-23.5&lon=-46.6", nil, geocoderRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewWeatherHandler(
+				&stubViaCEP{addr: tt.addr},
+				&stubGeoClient{location: &openweathermap.GeoLocation{Lat: -23.55, Lon: -46.63}},
+				&stubWeather{tempC: 25},
+			)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weather?location=true&"+tt.query, nil))
+			var resp domain.WeatherResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid json: %v", err)
+			}
+			if resp.Location == nil || resp.Location.Geocoder != tt.want {
+				t.Fatalf("expected geocoder %s, got %+v", tt.want, resp.Location)
+			}
+			if resp.Location.ObservedAt != nil {
+				t.Fatalf("expected no observation time when the provider does not report it")
+			}
+		})
+	}
+}
+
+func TestWeatherHandler_InvalidLocationFlag(t *testing.T) {
+	h := NewWeatherHandler(&stubViaCEP{}, &stubGeoClient{}, &stubWeather{})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/weather?cep=01153000&location=maybe", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,69 @@
+package singleflight
+
+import (
+	"context"
+	"sync"
+)
+
+// Group coalesces concurrent calls that share the same key: only the first
+// caller runs fn, the others wait for it and receive the same result.
+type Group[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*call[V]
+}
+
+type call[V any] struct {
+	done chan struct{}
+	val  V
+	err  error
+}
+
+// Do runs fn once per in-flight key. fn receives a context that is detached
+// from the caller's cancellation, so one caller giving up does not fail the
+// others, but it keeps the deadline of the caller that started the call.
+// Each caller still stops waiting when its own ctx is done. The shared
+// result reports whether the value came from another caller's call.
+func (g *Group[K, V]) Do(ctx context.Context, key K, fn func(context.Context) (V, error)) (v V, shared bool, err error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[K]*call[V])
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		c = &call[V]{done: make(chan struct{})}
+		g.calls[key] = c
+		go g.run(ctx, key, c, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, ok, c.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ok, ctx.Err()
+	}
+}
+
+// detach drops ctx's cancellation while preserving its deadline, so the
+// shared call is still bounded by the time budget of the caller that
+// started it.
+func detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached := context.WithoutCancel(ctx)
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(detached, deadline)
+	}
+	return detached, func() {}
+}
+
+func (g *Group[K, V]) run(ctx context.Context, key K, c *call[V], fn func(context.Context) (V, error)) {
+	ctx, cancel := detach(ctx)
+	defer cancel()
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	c.val, c.err = fn(ctx)
+}
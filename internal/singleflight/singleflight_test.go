@@ -0,0 +1,123 @@
+package singleflight
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroup_CoalescesConcurrentCalls(t *testing.T) {
+	var g Group[string, int]
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	fn := func(ctx context.Context) (int, error) {
+		calls.Add(1)
+		<-release
+		return 42, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, _, err := g.Do(context.Background(), "key", fn)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+
+	// Give every goroutine the chance to join the in-flight call.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Fatalf("expected 1 call, got %d", calls.Load())
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Fatalf("result %d = %d, expected 42", i, v)
+		}
+	}
+}
+
+func TestGroup_CallerCancellationDoesNotAffectOthers(t *testing.T) {
+	var g Group[string, int]
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (int, error) {
+		<-release
+		return 7, ctx.Err()
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, _, err := g.Do(leaderCtx, "key", fn)
+		leaderErr <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	followerVal := make(chan int, 1)
+	go func() {
+		v, _, _ := g.Do(context.Background(), "key", fn)
+		followerVal <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	if err := <-leaderErr; err != context.Canceled {
+		t.Fatalf("expected leader to observe its cancellation, got %v", err)
+	}
+
+	close(release)
+	if v := <-followerVal; v != 7 {
+		t.Fatalf("expected follower to receive 7, got %d", v)
+	}
+}
+
+func TestGroup_KeepsCallerDeadline(t *testing.T) {
+	var g Group[string, int]
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	_, _, err := g.Do(ctx, "key", func(ctx context.Context) (int, error) {
+		got, ok := ctx.Deadline()
+		if !ok || !got.Equal(deadline) {
+			t.Errorf("expected deadline %v, got %v (set: %t)", deadline, got, ok)
+		}
+		return 0, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGroup_DeadlineStopsSharedCall(t *testing.T) {
+	var g Group[string, int]
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	fnErr := make(chan error, 1)
+	g.Do(ctx, "key", func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		fnErr <- ctx.Err()
+		return 0, ctx.Err()
+	})
+
+	select {
+	case err := <-fnErr:
+		if err != context.DeadlineExceeded {
+			t.Fatalf("expected deadline exceeded, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("shared call outlived the caller's deadline")
+	}
+}
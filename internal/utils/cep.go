@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrEmptyCEP            = errors.New("CEP is empty")
+	ErrCEPInvalidCharacter = errors.New("CEP contains an invalid character")
+	ErrCEPLength           = errors.New("CEP must contain exactly 8 digits")
+	ErrCEPSeparator        = errors.New("CEP separators must follow the 00.000-000 layout")
+	ErrCEPOutOfRange       = errors.New("CEP is not in the range of any state")
+)
+
+// formattedCEPRegex accepts the layouts commonly typed in forms:
+// 01153000, 01153-000 and 01.153-000.
+var formattedCEPRegex = regexp.MustCompile(`^\d{2}\.?\d{3}-?\d{3}$`)
+
+// CEP is a Brazilian postal code normalized to its eight digits.
+type CEP string
+
+// ParseCEP normalizes user-typed input into a CEP. Surrounding whitespace,
+// the hyphen and the dot are accepted; anything else is reported in the
+// returned error. CEPs outside the Correios state ranges, such as 00000000,
+// are rejected as well.
+func ParseCEP(s string) (CEP, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", ErrEmptyCEP
+	}
+
+	digits := 0
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r != '-' && r != '.':
+			return "", fmt.Errorf("%w: %q at position %d", ErrCEPInvalidCharacter, r, i+1)
+		}
+	}
+	if digits != 8 {
+		return "", fmt.Errorf("%w, got %d", ErrCEPLength, digits)
+	}
+	if !formattedCEPRegex.MatchString(s) {
+		return "", ErrCEPSeparator
+	}
+
+	cep := CEP(strings.NewReplacer("-", "", ".", "").Replace(s))
+	if cep.State() == "" {
+		return "", ErrCEPOutOfRange
+	}
+	return cep, nil
+}
+
+// String returns the eight digits of the CEP, the form the providers expect.
+func (c CEP) String() string {
+	return string(c)
+}
+
+// Format returns the CEP in the 00000-000 layout.
+func (c CEP) Format() string {
+	if len(c) != 8 {
+		return string(c)
+	}
+	return string(c[:5]) + "-" + string(c[5:])
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCEP(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    CEP
+		wantErr error
+	}{
+		{name: "digits only", input: "01153000", want: "01153000"},
+		{name: "with hyphen", input: "01153-000", want: "01153000"},
+		{name: "with dot and hyphen", input: "01.153-000", want: "01153000"},
+		{name: "surrounding spaces", input: " 01153000 ", want: "01153000"},
+		{name: "empty", input: "  ", wantErr: ErrEmptyCEP},
+		{name: "letters", input: "0115300a", wantErr: ErrCEPInvalidCharacter},
+		{name: "inner space", input: "01153 000", wantErr: ErrCEPInvalidCharacter},
+		{name: "too short", input: "0115-300", wantErr: ErrCEPLength},
+		{name: "too long", input: "011530000", wantErr: ErrCEPLength},
+		{name: "misplaced hyphen", input: "0115-3000", wantErr: ErrCEPSeparator},
+		{name: "repeated separators", input: "01153--000", wantErr: ErrCEPSeparator},
+		{name: "all zeros", input: "00000000", wantErr: ErrCEPOutOfRange},
+		{name: "below the first range", input: "00999-999", wantErr: ErrCEPOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCEP(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCEP(%q) error = %v, expected %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("ParseCEP(%q) = %q, expected %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCEP_ReportsPosition(t *testing.T) {
+	_, err := ParseCEP("01153@00")
+	if err == nil || err.Error() != `CEP contains an invalid character: '@' at position 6` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCEP_Format(t *testing.T) {
+	cep := CEP("01153000")
+	if cep.Format() != "01153-000" {
+		t.Fatalf("unexpected format %q", cep.Format())
+	}
+	if cep.String() != "01153000" {
+		t.Fatalf("unexpected string %q", cep.String())
+	}
+}
+
+func TestCEP_State(t *testing.T) {
+	tests := map[CEP]string{
+		"01000000": "SP",
+		"19999999": "SP",
+		"20040020": "RJ",
+		"68900000": "AP",
+		"69301000": "RR",
+		"69400000": "AM",
+		"72800000": "GO",
+		"73000000": "DF",
+		"99999999": "RS",
+		"00999999": "",
+	}
+	for cep, want := range tests {
+		if got := cep.State(); got != want {
+			t.Errorf("CEP(%s).State() = %q, expected %q", cep, got, want)
+		}
+	}
+}
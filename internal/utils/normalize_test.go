@@ -0,0 +1,26 @@
+package utils
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Accents and case", input: "São Paulo", expected: "sao paulo"},
+		{name: "Extra whitespace", input: "  Santa   Helena ", expected: "santa helena"},
+		{name: "Cedilla", input: "FOZ DO IGUAÇU", expected: "foz do iguacu"},
+		{name: "Already normalized", input: "bom jesus", expected: "bom jesus"},
+		{name: "Empty", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NormalizeName(tt.input)
+			if result != tt.expected {
+				t.Errorf("NormalizeName(%q) = %q, expected %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
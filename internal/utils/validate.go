@@ -1,17 +1,14 @@
 package utils
 
-import (
-	"regexp"
-)
-
-var cepRegex = regexp.MustCompile(`^\d{8}$`)
-
+// IsValidCEP reports whether cep is already in the canonical eight-digit form
+// accepted by ParseCEP.
 func IsValidCEP(cep string) bool {
-	return cepRegex.MatchString(cep)
+	c, err := ParseCEP(cep)
+	return err == nil && c.String() == cep
 }
 
 func CelsiusToFahrenheit(c float64) float64 {
-	return c*1.8 + 32
+	return c*9/5 + 32
 }
 
 func CelsiusToKelvin(c float64) float64 {
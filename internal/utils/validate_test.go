@@ -56,6 +56,11 @@ func TestIsValidCEP(t *testing.T) {
 			cep:      "01153@00",
 			expected: false,
 		},
+		{
+			name:     "Invalid CEP outside the state ranges",
+			cep:      "00000000",
+			expected: false,
+		},
 	}
 
 	for _, tt := range tests {